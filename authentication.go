@@ -4,16 +4,24 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
+	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/types"
+	"golang.org/x/oauth2"
 )
 
+// defaultTokenRefreshBefore is how long before expiry a token obtained through a TokenSource is proactively refreshed.
+const defaultTokenRefreshBefore = 1 * time.Minute
+
 type AuthenticationService interface {
 	LoginOauth(ctx context.Context, accessKey, secretKey string) (string, error)
+	TokenSource(accessKey, secretKey string) oauth2.TokenSource
 }
 
 type AuthenticationServiceOp struct {
@@ -40,20 +48,58 @@ func (svc *AuthenticationServiceOp) LoginOauth(ctx context.Context, accessKey, s
 		return svc.bearerToken, nil
 	}
 
+	token, err := svc.requestToken(ctx, accessKey, secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	svc.tokenExpiry = token.Expiry
+
+	// Store the access token
+	svc.bearerToken = token.AccessToken
+	svc.SessionToken = token.AccessToken
+
+	svc.Logger.Debug("session established")
+
+	return svc.bearerToken, nil
+}
+
+// TokenSource returns an oauth2.TokenSource that issues tokens using the client credentials grant for the given
+// API key and API secret key. Tokens are cached and refreshed shortly before they expire.
+func (svc *AuthenticationServiceOp) TokenSource(accessKey, secretKey string) oauth2.TokenSource {
+	return newClientCredentialsTokenSource(svc, accessKey, secretKey)
+}
+
+// tokenURL returns the token endpoint set by WithEnvironment, falling back to the named environment that matches
+// the client's API host.
+func (svc *AuthenticationServiceOp) tokenURL() string {
+	if svc.Client.tokenURL != "" {
+		return svc.Client.tokenURL
+	}
+
+	if env, ok := environmentForHost(svc.Client.BaseURL.Host); ok {
+		return env.TokenURL
+	}
+	return ""
+}
+
+// tokenHTTPClient returns an HTTP client for token requests that bypasses the client's token transport.
+func (svc *AuthenticationServiceOp) tokenHTTPClient() *http.Client {
+	if t, ok := svc.Client.HTTPClient.Transport.(*tokenTransport); ok {
+		return &http.Client{Transport: t.Base, Timeout: svc.Client.HTTPClient.Timeout}
+	}
+	return svc.Client.HTTPClient
+}
+
+// requestToken exchanges an API key and API secret key for an access token using the client credentials grant.
+func (svc *AuthenticationServiceOp) requestToken(ctx context.Context, accessKey, secretKey string) (*oauth2.Token, error) {
 	// Encode the client ID and client secret to create Basic Authentication
 	authHeader := base64.StdEncoding.EncodeToString([]byte(accessKey + ":" + secretKey))
 
 	// Set the URL for the token endpoint
-	var tokenURL string
-
-	if svc.Client.BaseURL.Host == "api.megaport.com" {
-		tokenURL = "https://auth-m2m.megaport.com/oauth2/token"
-	} else if svc.Client.BaseURL.Host == "api-staging.megaport.com" {
-		tokenURL = "https://oauth-m2m-staging.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
-	} else if svc.Client.BaseURL.Host == "api-uat.megaport.com" {
-		tokenURL = "https://oauth-m2m-uat.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
-	} else if svc.Client.BaseURL.Host == "api-uat2.megaport.com" {
-		tokenURL = "https://oauth-m2m-uat2.auth.ap-southeast-2.amazoncognito.com/oauth2/token"
+	tokenURL := svc.tokenURL()
+	if tokenURL == "" {
+		return nil, newClientError(ErrValidation, fmt.Sprintf("%s: %s", mega_err.ERR_TOKEN_URL_NOT_SET, svc.Client.BaseURL.Host))
 	}
 
 	// Create form data for the request body
@@ -61,9 +107,9 @@ func (svc *AuthenticationServiceOp) LoginOauth(ctx context.Context, accessKey, s
 	data.Set("grant_type", "client_credentials")
 
 	// Create an HTTP request
-	req, err := svc.Client.NewRequest(ctx, "POST", tokenURL, nil)
+	req, err := svc.Client.NewRequest(ctx, http.MethodPost, tokenURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.URL.RawQuery = data.Encode()
@@ -72,14 +118,10 @@ func (svc *AuthenticationServiceOp) LoginOauth(ctx context.Context, accessKey, s
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Authorization", "Basic "+authHeader)
 
-	svc.Logger.Debug(req.Header.Get("Authorization"))
-	svc.Logger.Debug(req.Header.Get("Content-Type"))
-
-	// Create an HTTP client and send the request
 	svc.Logger.Debug("login request", "token_url", tokenURL)
-	resp, resErr := svc.Client.Do(ctx, req, nil)
+	resp, resErr := DoRequestWithClient(ctx, svc.tokenHTTPClient(), req)
 	if resErr != nil {
-		return "", resErr
+		return nil, resErr
 	}
 
 	defer resp.Body.Close()
@@ -87,27 +129,137 @@ func (svc *AuthenticationServiceOp) LoginOauth(ctx context.Context, accessKey, s
 	// Read the response body
 	body, fileErr := io.ReadAll(resp.Body)
 	if fileErr != nil {
-		return "", fileErr
+		return nil, fileErr
 	}
 
 	// Parse the response JSON to extract the access token and expiration time
 	authResponse := types.AccessTokenResponse{}
-	if parseErr := json.Unmarshal(body, &authResponse); parseErr != nil {
-		return "", parseErr
+	parseErr := json.Unmarshal(body, &authResponse)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || authResponse.Error != "" {
+		errorResponse := errorResponseFromBody(resp, body)
+		errorResponse.authentication = true
+		if errorResponse.Message == "" && authResponse.Error != "" {
+			errorResponse.Message = "authentication error: " + authResponse.Error
+		}
+		return nil, errorResponse
+	}
+	if parseErr != nil {
+		return nil, parseErr
 	}
 
-	if authResponse.Error != "" {
-		return "", errors.New("authentication error: " + authResponse.Error)
+	return &oauth2.Token{
+		AccessToken: authResponse.AccessToken,
+		TokenType:   authResponse.TokenType,
+		Expiry:      time.Now().Add(time.Duration(authResponse.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// clientCredentialsTokenSource is an oauth2.TokenSource that caches a client credentials token and requests a new
+// one once the cached token is within refreshBefore of its expiry.
+type clientCredentialsTokenSource struct {
+	auth          *AuthenticationServiceOp
+	accessKey     string
+	secretKey     string
+	refreshBefore time.Duration
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func newClientCredentialsTokenSource(auth *AuthenticationServiceOp, accessKey, secretKey string) *clientCredentialsTokenSource {
+	return &clientCredentialsTokenSource{
+		auth:          auth,
+		accessKey:     accessKey,
+		secretKey:     secretKey,
+		refreshBefore: defaultTokenRefreshBefore,
 	}
+}
 
-	// Calculate the token expiration time
-	svc.tokenExpiry = time.Now().Add(time.Duration(authResponse.ExpiresIn) * time.Second)
+func (ts *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	return ts.tokenWithContext(context.Background())
+}
 
-	// Store the access token
-	svc.bearerToken = authResponse.AccessToken
-	svc.SessionToken = authResponse.AccessToken
+func (ts *clientCredentialsTokenSource) tokenWithContext(ctx context.Context) (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 
-	svc.Logger.Debug("session established")
+	if ts.token != nil && time.Until(ts.token.Expiry) > ts.refreshBefore {
+		return ts.token, nil
+	}
 
-	return svc.bearerToken, nil
+	token, err := ts.auth.requestToken(ctx, ts.accessKey, ts.secretKey)
+	if err != nil {
+		return nil, err
+	}
+	ts.token = token
+	return token, nil
+}
+
+// invalidate drops the cached token if it is still the given token, forcing the next call to request a new one.
+func (ts *clientCredentialsTokenSource) invalidate(token *oauth2.Token) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = nil
+	}
+}
+
+// tokenTransport is an http.RoundTripper that authorizes requests with a token from Source. A request rejected
+// with 401 Unauthorized is retried once with a newly issued token.
+type tokenTransport struct {
+	Base   http.RoundTripper
+	Source *clientCredentialsTokenSource
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.tokenWithContext(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base().RoundTrip(authorizeRequest(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The body has already been consumed and cannot be replayed.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	t.Source.invalidate(token)
+	freshToken, err := t.Source.tokenWithContext(req.Context())
+	if err != nil {
+		return resp, nil
+	}
+
+	retryReq := authorizeRequest(req, freshToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retryReq.Body = body
+	}
+
+	io.Copy(io.Discard, resp.Body) // nolint
+	resp.Body.Close()
+
+	return t.base().RoundTrip(retryReq)
+}
+
+func (t *tokenTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// authorizeRequest returns a copy of req with its Authorization header set from token.
+func authorizeRequest(req *http.Request, token *oauth2.Token) *http.Request {
+	authorized := req.Clone(req.Context())
+	token.SetAuthHeader(authorized)
+	return authorized
 }
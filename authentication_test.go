@@ -2,11 +2,17 @@ package megaport
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/shared"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,10 +24,6 @@ var megaportClient *Client
 
 var programLevel = new(slog.LevelVar)
 
-const (
-	MEGAPORTURL = "https://api-staging.megaport.com/"
-)
-
 func TestMain(m *testing.M) {
 
 	accessKey = os.Getenv("MEGAPORT_ACCESS_KEY")
@@ -34,7 +36,7 @@ func TestMain(m *testing.M) {
 
 	var err error
 
-	megaportClient, err = New(httpClient, SetBaseURL(MEGAPORTURL), SetLogHandler(handler))
+	megaportClient, err = New(httpClient, WithEnvironment(EnvironmentStaging), SetLogHandler(handler))
 	if err != nil {
 		log.Fatalf("could not initialize megaport test client: %s", err.Error())
 	}
@@ -69,3 +71,158 @@ func TestLoginOauth(t *testing.T) {
 	megaportClient.Logger.Info("", "token", token)
 	megaportClient.SessionToken = token
 }
+
+// newCredentialsTestClient returns a client authorized with WithCredentials against the test server, whose token
+// endpoint issues sequentially numbered tokens valid for expiresIn seconds.
+func newCredentialsTestClient(t *testing.T, expiresIn int) (*Client, *int) {
+	issued := 0
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		assert.Equal(t, "client_credentials", r.URL.Query().Get("grant_type"))
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "test-access-key", user)
+		assert.Equal(t, "test-secret-key", pass)
+
+		issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, issued, expiresIn)
+	})
+
+	c, err := New(nil, WithEnvironment(CustomEnvironment(server.URL, server.URL+"/oauth2/token")), WithCredentials("test-access-key", "test-secret-key"))
+	assert.NoError(t, err)
+	return c, &issued
+}
+
+func TestWithCredentials_cachesToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q}}`, testVXCUID)
+	})
+
+	c, issued := newCredentialsTestClient(t, 3600)
+
+	for i := 0; i < 3; i++ {
+		_, err := c.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, *issued)
+}
+
+func TestWithCredentials_refreshesBeforeExpiry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q}}`, testVXCUID)
+	})
+
+	// Tokens that expire within the refresh window are never reused.
+	c, issued := newCredentialsTestClient(t, 30)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, *issued)
+}
+
+func TestWithCredentials_retriesOnUnauthorized(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/vxc/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Unauthorized","terms":"","data":""}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"name":"Renamed VXC"`)
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+
+	c, issued := newCredentialsTestClient(t, 3600)
+
+	updated, err := c.VXCService.UpdateVXC(ctx, &UpdateVXCRequest{VXCID: testVXCUID, Name: "Renamed VXC", RateLimit: 100})
+	assert.NoError(t, err)
+	assert.True(t, updated.IsUpdated)
+	assert.Equal(t, 2, *issued)
+}
+
+func TestTokenSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"Bearer","expires_in":3600}`)
+	})
+	assert.NoError(t, WithEnvironment(CustomEnvironment(server.URL, server.URL+"/oauth2/token"))(client))
+
+	token, err := client.AuthenticationService.TokenSource("test-access-key", "test-secret-key").Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+}
+
+func TestLoginOauth_rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"invalid client", http.StatusBadRequest, `{"error":"invalid_client"}`},
+		{"unauthorized", http.StatusUnauthorized, `{"error":"invalid_client"}`},
+		{"error with success status", http.StatusOK, `{"error":"invalid_grant"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(headerRequestID, "req-123")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+			assert.NoError(t, WithEnvironment(CustomEnvironment(server.URL, server.URL+"/oauth2/token"))(client))
+
+			_, err := client.AuthenticationService.LoginOauth(ctx, "test-access-key", "test-secret-key")
+			assert.ErrorIs(t, err, ErrUnauthorized)
+			assert.NotErrorIs(t, err, ErrValidation)
+
+			var errResponse *ErrorResponse
+			assert.True(t, errors.As(err, &errResponse))
+			assert.Equal(t, tt.status, errResponse.StatusCode)
+			assert.Equal(t, "req-123", errResponse.RequestID)
+			assert.Contains(t, errResponse.Message, "authentication error")
+		})
+	}
+}
+
+func TestLoginOauth_rateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	assert.NoError(t, WithEnvironment(CustomEnvironment(server.URL, server.URL+"/oauth2/token"))(client))
+
+	_, err := client.AuthenticationService.LoginOauth(ctx, "test-access-key", "test-secret-key")
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestLoginOauth_noTokenURL(t *testing.T) {
+	c, err := New(nil, SetBaseURL("http://127.0.0.1:8080/"))
+	assert.NoError(t, err)
+
+	_, err = c.AuthenticationService.LoginOauth(ctx, "test-access-key", "test-secret-key")
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), mega_err.ERR_TOKEN_URL_NOT_SET)
+}
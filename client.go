@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -18,14 +17,11 @@ import (
 
 	"github.com/google/go-querystring/query"
 	"github.com/hashicorp/go-retryablehttp"
-	"github.com/megaport/megaportgo/mega_err"
-	"github.com/megaport/megaportgo/types"
 	"golang.org/x/oauth2"
 )
 
 const (
 	libraryVersion = "1.0"
-	defaultBaseURL = "https://api-staging.megaport.com/"
 	userAgent      = "Go-Megaport-Library/" + libraryVersion
 	mediaType      = "application/json"
 
@@ -54,6 +50,10 @@ type Client struct {
 	// Session Token for client
 	SessionToken string
 
+	// OAuth token endpoint set by WithEnvironment or the default environment. When empty it is derived from the
+	// BaseURL host.
+	tokenURL string
+
 	// Optional function called after every successful request made to the DO APIs
 	onRequestCompleted RequestCompletionCallback
 
@@ -62,6 +62,11 @@ type Client struct {
 	PortService           PortService
 	ProductService        ProductService
 	LocationService       LocationService
+	VXCService            VXCService
+	MCRService            MCRService
+	MVEService            MVEService
+	PartnerService        PartnerService
+	IXService             IXService
 
 	// Optional extra HTTP headers to set on every request to the API.
 	headers map[string]string
@@ -69,6 +74,9 @@ type Client struct {
 	// Optional retry values. Setting the RetryConfig.RetryMax value enables automatically retrying requests
 	// that fail with 429 or 500-level response codes using the go-retryablehttp client
 	RetryConfig RetryConfig
+
+	// Options used by the WaitFor*Provisioning methods. Zero values fall back to the defaults in WaitOptions.
+	WaitOptions WaitOptions
 }
 
 // RetryConfig sets the values used for enabling retries and backoffs for
@@ -117,11 +125,20 @@ type ErrorResponse struct {
 	// Error message
 	Message string `json:"message"`
 
+	// HTTP status code of the response
+	StatusCode int `json:"-"`
+
+	// Additional detail from the API's "data" field, such as which field failed validation.
+	Data string `json:"-"`
+
 	// RequestID returned from the API, useful to contact support.
 	RequestID string `json:"request_id"`
 
 	// Attempts is the number of times the request was attempted when retries are enabled.
 	Attempts int
+
+	// authentication is set for responses from the OAuth token endpoint.
+	authentication bool
 }
 
 func addOptions(s string, opt interface{}) (string, error) {
@@ -182,10 +199,12 @@ func NewClient(httpClient *http.Client, base *url.URL) *Client {
 	}
 
 	var baseURL *url.URL
+	var tokenURL string
 	if base != nil {
 		baseURL = base
 	} else {
-		baseURL, _ = url.Parse(defaultBaseURL)
+		baseURL, _ = url.Parse(defaultEnvironment.BaseURL)
+		tokenURL = defaultEnvironment.TokenURL
 	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
@@ -195,12 +214,18 @@ func NewClient(httpClient *http.Client, base *url.URL) *Client {
 		BaseURL:    baseURL,
 		UserAgent:  userAgent,
 		Logger:     logger,
+		tokenURL:   tokenURL,
 	}
 
 	c.AuthenticationService = NewAuthenticationServiceOp(c)
 	c.ProductService = NewProductServiceOp(c)
 	c.PortService = NewPortServiceOp(c)
 	c.LocationService = NewLocationServiceOp(c)
+	c.VXCService = NewVXCServiceOp(c)
+	c.MCRService = NewMCRServiceOp(c)
+	c.MVEService = NewMVEServiceOp(c)
+	c.PartnerService = NewPartnerServiceOp(c)
+	c.IXService = NewIXServiceOp(c)
 
 	c.headers = make(map[string]string)
 
@@ -260,14 +285,20 @@ func New(httpClient *http.Client, opts ...ClientOpt) (*Client, error) {
 			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
 		}
 
-		var source *oauth2.Transport
-		if _, ok := c.HTTPClient.Transport.(*oauth2.Transport); ok {
-			source = c.HTTPClient.Transport.(*oauth2.Transport)
-		}
+		// Keep any token-authorizing transport as the outermost layer so that retried requests are authorized too.
+		transport := c.HTTPClient.Transport
 		c.HTTPClient = retryableClient.StandardClient()
-		c.HTTPClient.Transport = &oauth2.Transport{
-			Base:   c.HTTPClient.Transport,
-			Source: source.Source,
+		switch t := transport.(type) {
+		case *oauth2.Transport:
+			c.HTTPClient.Transport = &oauth2.Transport{
+				Base:   c.HTTPClient.Transport,
+				Source: t.Source,
+			}
+		case *tokenTransport:
+			c.HTTPClient.Transport = &tokenTransport{
+				Base:   c.HTTPClient.Transport,
+				Source: t.Source,
+			}
 		}
 
 	}
@@ -275,7 +306,8 @@ func New(httpClient *http.Client, opts ...ClientOpt) (*Client, error) {
 	return c, nil
 }
 
-// SetBaseURL is a client option for setting the base URL.
+// SetBaseURL is a client option for setting the base URL. The OAuth token endpoint is then derived from the base
+// URL's host, replacing any set by WithEnvironment; use WithEnvironment to set both together.
 func SetBaseURL(bu string) ClientOpt {
 	return func(c *Client) error {
 		u, err := url.Parse(bu)
@@ -284,6 +316,7 @@ func SetBaseURL(bu string) ClientOpt {
 		}
 
 		c.BaseURL = u
+		c.tokenURL = ""
 		return nil
 	}
 }
@@ -327,6 +360,24 @@ func WithRetryAndBackoffs(retryConfig RetryConfig) ClientOpt {
 	}
 }
 
+// WithCredentials is a client option that authorizes every request with an OAuth token issued for the given API key
+// and API secret key. Tokens are refreshed automatically before they expire, and a request rejected with 401
+// Unauthorized is retried once with a new token.
+func WithCredentials(accessKey, secretKey string) ClientOpt {
+	return func(c *Client) error {
+		source := newClientCredentialsTokenSource(NewAuthenticationServiceOp(c), accessKey, secretKey)
+
+		// Copy the HTTP client so a shared client such as http.DefaultClient is left untouched.
+		httpClient := *c.HTTPClient
+		httpClient.Transport = &tokenTransport{
+			Base:   httpClient.Transport,
+			Source: source,
+		}
+		c.HTTPClient = &httpClient
+		return nil
+	}
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
 // value pointed to by body is JSON encoded and included in as the request body.
@@ -433,36 +484,50 @@ func (r *ErrorResponse) Error() string {
 		attempted = fmt.Sprintf("; giving up after %d attempt(s)", r.Attempts)
 	}
 
+	message := r.Message
+	if r.Data != "" {
+		message += ": " + r.Data
+	}
+
 	if r.RequestID != "" {
 		return fmt.Sprintf("%v %v: %d (request %q) %v%s",
-			r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.RequestID, r.Message, attempted)
+			r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.RequestID, message, attempted)
 	}
 	return fmt.Sprintf("%v %v: %d %v%s",
-		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message, attempted)
+		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, message, attempted)
+}
+
+// Is reports whether target is the sentinel error for the response's HTTP status code. A token request rejected
+// with a status below 500, other than 429 Too Many Requests, is reported as ErrUnauthorized.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.authentication && r.StatusCode < http.StatusInternalServerError && r.StatusCode != http.StatusTooManyRequests {
+		return target == ErrUnauthorized
+	}
+
+	switch target {
+	case ErrNotFound:
+		return r.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return r.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return r.StatusCode == http.StatusBadRequest || r.StatusCode == http.StatusUnprocessableEntity
+	case ErrConflict:
+		return r.StatusCode == http.StatusConflict
+	}
+	return false
 }
 
-// IsErrorResponse returns an error report if an error response is detected from the API.
+// IsErrorResponse returns an error report if an error response is detected from the API. A response with a status
+// code other than expectedReturnCode is reported as an *ErrorResponse, the same as from CheckResponse.
 func (c *Client) IsErrorResponse(response *http.Response, responseErr *error, expectedReturnCode int) (bool, error) {
 	if *responseErr != nil {
 		return true, *responseErr
 	}
 
 	if response.StatusCode != expectedReturnCode {
-		body, fileErr := io.ReadAll(response.Body)
-
-		if fileErr != nil {
-			return false, fileErr
-		}
-
-		errResponse := types.ErrorResponse{}
-		errParseErr := json.Unmarshal([]byte(body), &errResponse)
-
-		if errParseErr != nil {
-			errorReport := fmt.Sprintf(mega_err.ERR_PARSING_ERR_RESPONSE, response.StatusCode, errParseErr.Error(), string(body))
-			return true, errors.New(errorReport)
-		}
-
-		return true, errors.New(errResponse.Message + ": " + errResponse.Data)
+		return true, newErrorResponse(response)
 	}
 
 	return false, nil
@@ -470,19 +535,36 @@ func (c *Client) IsErrorResponse(response *http.Response, responseErr *error, ex
 
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// body, or a JSON response body that maps to ErrorResponse. Any other response body is used as the error message.
 // If the API error response does not include the request ID in its body, the one from its header will be used.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	return newErrorResponse(r)
+}
+
+// newErrorResponse builds an *ErrorResponse from an API response, consuming its body.
+func newErrorResponse(r *http.Response) *ErrorResponse {
 	data, err := io.ReadAll(r.Body)
-	if err == nil && len(data) > 0 {
-		err := json.Unmarshal(data, errorResponse)
-		if err != nil {
+	if err != nil {
+		data = nil
+	}
+	return errorResponseFromBody(r, data)
+}
+
+// errorResponseFromBody builds an *ErrorResponse from an API response whose body has already been read.
+func errorResponseFromBody(r *http.Response, data []byte) *ErrorResponse {
+	errorResponse := &ErrorResponse{Response: r, StatusCode: r.StatusCode}
+	if len(data) > 0 {
+		body := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+		if err := json.Unmarshal(data, errorResponse); err != nil {
 			errorResponse.Message = string(data)
+		} else if err := json.Unmarshal(data, &body); err == nil {
+			errorResponse.Data = errorData(body.Data)
 		}
 	}
 
@@ -498,6 +580,24 @@ func CheckResponse(r *http.Response) error {
 	return errorResponse
 }
 
+// errorData returns the API's error data as text. The field is usually a string but may be any JSON value.
+func errorData(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	compacted := new(bytes.Buffer)
+	if err := json.Compact(compacted, raw); err != nil {
+		return string(raw)
+	}
+	return compacted.String()
+}
+
 // PtrTo returns a pointer to the provided input.
 func PtrTo[T any](v T) *T {
 	return &v
@@ -73,8 +73,8 @@ func testURLParseError(t *testing.T, err error) {
 }
 
 func testClientDefaultBaseURL(t *testing.T, c *Client) {
-	if c.BaseURL == nil || c.BaseURL.String() != defaultBaseURL {
-		t.Errorf("NewClient BaseURL = %v, expected %v", c.BaseURL, defaultBaseURL)
+	if c.BaseURL == nil || c.BaseURL.String() != defaultEnvironment.BaseURL {
+		t.Errorf("NewClient BaseURL = %v, expected %v", c.BaseURL, defaultEnvironment.BaseURL)
 	}
 }
 
@@ -139,7 +139,7 @@ func TestNew(t *testing.T) {
 func TestNewRequest_get(t *testing.T) {
 	c := NewClient(nil, nil)
 
-	inURL, outURL := "/foo", defaultBaseURL+"foo"
+	inURL, outURL := "/foo", defaultEnvironment.BaseURL+"foo"
 	req, _ := c.NewRequest(ctx, http.MethodGet, inURL, nil)
 
 	// test relative URL was expanded
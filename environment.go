@@ -0,0 +1,86 @@
+package megaport
+
+import "net/url"
+
+// Environment pairs a Megaport API base URL with the OAuth token endpoint that issues tokens for it.
+type Environment struct {
+	Name     string
+	BaseURL  string
+	TokenURL string
+}
+
+var (
+	// EnvironmentProduction is the live Megaport API. Orders placed here are billed.
+	EnvironmentProduction = Environment{
+		Name:     "production",
+		BaseURL:  "https://api.megaport.com/",
+		TokenURL: "https://auth-m2m.megaport.com/oauth2/token",
+	}
+
+	// EnvironmentStaging is the Megaport staging API.
+	EnvironmentStaging = Environment{
+		Name:     "staging",
+		BaseURL:  "https://api-staging.megaport.com/",
+		TokenURL: "https://oauth-m2m-staging.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+
+	// EnvironmentUAT is the Megaport UAT API.
+	EnvironmentUAT = Environment{
+		Name:     "uat",
+		BaseURL:  "https://api-uat.megaport.com/",
+		TokenURL: "https://oauth-m2m-uat.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+
+	// EnvironmentUAT2 is the Megaport UAT2 API.
+	EnvironmentUAT2 = Environment{
+		Name:     "uat2",
+		BaseURL:  "https://api-uat2.megaport.com/",
+		TokenURL: "https://oauth-m2m-uat2.auth.ap-southeast-2.amazoncognito.com/oauth2/token",
+	}
+)
+
+// defaultEnvironment is the environment a client uses unless WithEnvironment or SetBaseURL is given. It is production,
+// so that credentials given with WithCredentials alone are used against the API they were issued for.
+var defaultEnvironment = EnvironmentProduction
+
+// environments are the named Megaport environments, used to find the token endpoint for a base URL.
+var environments = []Environment{EnvironmentProduction, EnvironmentStaging, EnvironmentUAT, EnvironmentUAT2}
+
+// CustomEnvironment returns an Environment for an API and token endpoint that are not one of the named Megaport
+// environments, such as a local stand-in used in tests.
+func CustomEnvironment(baseURL, tokenURL string) Environment {
+	return Environment{
+		Name:     "custom",
+		BaseURL:  baseURL,
+		TokenURL: tokenURL,
+	}
+}
+
+// environmentForHost returns the named environment whose API is served from host.
+func environmentForHost(host string) (Environment, bool) {
+	for _, env := range environments {
+		u, err := url.Parse(env.BaseURL)
+		if err == nil && u.Host == host {
+			return env, true
+		}
+	}
+	return Environment{}, false
+}
+
+// WithEnvironment is a client option for setting the API base URL and OAuth token endpoint together.
+func WithEnvironment(env Environment) ClientOpt {
+	return func(c *Client) error {
+		if env.BaseURL == "" || env.TokenURL == "" {
+			return NewArgError("environment", "it must set both a base URL and a token URL")
+		}
+
+		u, err := url.Parse(env.BaseURL)
+		if err != nil {
+			return err
+		}
+
+		c.BaseURL = u
+		c.tokenURL = env.TokenURL
+		return nil
+	}
+}
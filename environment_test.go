@@ -0,0 +1,53 @@
+package megaport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEnvironment(t *testing.T) {
+	for _, env := range []Environment{EnvironmentProduction, EnvironmentStaging, EnvironmentUAT, EnvironmentUAT2} {
+		c, err := New(nil, WithEnvironment(env))
+		assert.NoError(t, err)
+		assert.Equal(t, env.BaseURL, c.BaseURL.String())
+		assert.Equal(t, env.TokenURL, NewAuthenticationServiceOp(c).tokenURL())
+	}
+
+	custom := CustomEnvironment("http://127.0.0.1:8080/", "http://127.0.0.1:8081/oauth2/token")
+	c, err := New(nil, WithEnvironment(custom))
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8080", c.BaseURL.Host)
+	assert.Equal(t, custom.TokenURL, NewAuthenticationServiceOp(c).tokenURL())
+
+	_, err = New(nil, WithEnvironment(CustomEnvironment("http://127.0.0.1:8080/", "")))
+	assert.IsType(t, &ArgError{}, err)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestTokenURLFromBaseURL(t *testing.T) {
+	c, err := New(nil, SetBaseURL(EnvironmentProduction.BaseURL))
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentProduction.TokenURL, NewAuthenticationServiceOp(c).tokenURL())
+
+	c, err = New(nil, SetBaseURL("http://127.0.0.1:8080/"))
+	assert.NoError(t, err)
+	_, err = c.AuthenticationService.LoginOauth(ctx, "test-access-key", "test-secret-key")
+	assert.Error(t, err)
+}
+
+func TestDefaultEnvironment(t *testing.T) {
+	assert.Equal(t, EnvironmentProduction, defaultEnvironment)
+
+	c, err := New(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultEnvironment.BaseURL, c.BaseURL.String())
+	assert.Equal(t, defaultEnvironment.TokenURL, NewAuthenticationServiceOp(c).tokenURL())
+}
+
+func TestSetBaseURLAfterWithEnvironment(t *testing.T) {
+	c, err := New(nil, WithEnvironment(EnvironmentProduction), SetBaseURL(EnvironmentStaging.BaseURL))
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentStaging.BaseURL, c.BaseURL.String())
+	assert.Equal(t, EnvironmentStaging.TokenURL, NewAuthenticationServiceOp(c).tokenURL())
+}
@@ -1,6 +1,28 @@
 package megaport
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors describing the kind of failure. Errors returned by the client match at most one of them with
+// errors.Is, whether they come from an API response (*ErrorResponse) or from a check made by the client itself.
+var (
+	// ErrNotFound is matched by 404 responses and by lookups that found nothing.
+	ErrNotFound = errors.New("megaport: not found")
+	// ErrUnauthorized is matched by 401 and 403 responses.
+	ErrUnauthorized = errors.New("megaport: unauthorized")
+	// ErrRateLimited is matched by 429 responses.
+	ErrRateLimited = errors.New("megaport: rate limited")
+	// ErrValidation is matched by 400 and 422 responses and by invalid arguments (*ArgError).
+	ErrValidation = errors.New("megaport: validation failed")
+	// ErrConflict is matched by 409 responses and by requests that conflict with a product's current state.
+	ErrConflict = errors.New("megaport: conflict")
+	// ErrProvisionTimeout is matched by *ProvisionTimeoutError.
+	ErrProvisionTimeout = errors.New("megaport: provisioning timed out")
+	// ErrAlreadyLocked is matched when locking a product that is already locked.
+	ErrAlreadyLocked = errors.New("megaport: already locked")
+)
 
 // ArgError is an error that represents an error with an input to godo. It
 // identifies the argument and the cause (if possible).
@@ -22,3 +44,33 @@ func NewArgError(arg, reason string) *ArgError {
 func (e *ArgError) Error() string {
 	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
 }
+
+// Is reports whether target is ErrValidation.
+func (e *ArgError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ClientError is an error detected by the client rather than returned by the API. Message is one of the mega_err
+// messages and Kind is the sentinel error it matches, or nil if it matches none.
+type ClientError struct {
+	Kind    error
+	Message string
+}
+
+var _ error = &ClientError{}
+
+func newClientError(kind error, message string) *ClientError {
+	return &ClientError{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+func (e *ClientError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the error's Kind.
+func (e *ClientError) Is(target error) bool {
+	return target == e.Kind
+}
@@ -0,0 +1,78 @@
+package megaport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponse_sentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusConflict, ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(headerRequestID, "req-123")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"message":"Request failed","terms":"","data":"vlan is already in use"}`)
+			})
+
+			_, err := client.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.NotErrorIs(t, err, ErrProvisionTimeout)
+
+			var errResponse *ErrorResponse
+			assert.True(t, errors.As(err, &errResponse))
+			assert.Equal(t, tt.status, errResponse.StatusCode)
+			assert.Equal(t, "req-123", errResponse.RequestID)
+			assert.Equal(t, "Request failed", errResponse.Message)
+			assert.Equal(t, "vlan is already in use", errResponse.Data)
+			assert.Contains(t, err.Error(), "Request failed: vlan is already in use")
+		})
+	}
+}
+
+func TestErrorResponse_unexpectedStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// A 2xx response that is not the expected status code is reported the same way as a failed request.
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"message":"Accepted","terms":"","data":{"productUid":"abc"}}`)
+	})
+
+	_, err := client.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+	var errResponse *ErrorResponse
+	assert.True(t, errors.As(err, &errResponse))
+	assert.Equal(t, http.StatusAccepted, errResponse.StatusCode)
+	assert.Equal(t, `{"productUid":"abc"}`, errResponse.Data)
+}
+
+func TestClientErrors(t *testing.T) {
+	err := error(newClientError(ErrAlreadyLocked, mega_err.ERR_PORT_ALREADY_LOCKED))
+	assert.ErrorIs(t, err, ErrAlreadyLocked)
+	assert.NotErrorIs(t, err, ErrConflict)
+	assert.EqualError(t, err, mega_err.ERR_PORT_ALREADY_LOCKED)
+
+	assert.ErrorIs(t, NewArgError("term", "it must be 1, 12, 24 or 36"), ErrValidation)
+	assert.ErrorIs(t, &ProvisionTimeoutError{Message: mega_err.ERR_PORT_PROVISION_TIMEOUT_EXCEED}, ErrProvisionTimeout)
+}
@@ -0,0 +1,339 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// IXService is an interface for interfacing with the IX endpoints
+// of the Megaport API.
+type IXService interface {
+	ListIXNetworks(ctx context.Context, locationID int) ([]*types.IXNetwork, error)
+	BuyIX(ctx context.Context, req *BuyIXRequest) (*types.IXOrderConfirmation, error)
+	ValidateIXOrder(ctx context.Context, req *BuyIXRequest) (*types.OrderQuote, error)
+	GetIX(ctx context.Context, req *GetIXRequest) (*types.IX, error)
+	UpdateIX(ctx context.Context, req *UpdateIXRequest) (*UpdateIXResponse, error)
+	DeleteIX(ctx context.Context, req *DeleteIXRequest) (*DeleteIXResponse, error)
+	WaitForIXProvisioning(ctx context.Context, ixID string) (bool, error)
+}
+
+// IXServiceOp handles communication with IX methods of the Megaport API.
+type IXServiceOp struct {
+	Client *Client
+}
+
+type BuyIXRequest struct {
+	PortUID string
+	Name    string
+	// NetworkServiceType is the name of the IX network to connect to, as returned by ListIXNetworks.
+	NetworkServiceType string
+	ASN                int
+	MACAddress         string
+	RateLimit          int
+	VLAN               int
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+type GetIXRequest struct {
+	IXID string
+}
+
+type UpdateIXRequest struct {
+	IXID       string
+	Name       string
+	RateLimit  int
+	VLAN       int
+	MACAddress string
+	ASN        int
+}
+
+type UpdateIXResponse struct {
+	IsUpdated bool
+}
+
+type DeleteIXRequest struct {
+	IXID      string
+	DeleteNow bool
+}
+
+type DeleteIXResponse struct {
+	IsDeleting bool
+}
+
+func NewIXServiceOp(c *Client) *IXServiceOp {
+	return &IXServiceOp{
+		Client: c,
+	}
+}
+
+// listIXNetworksOptions are the query parameters of the IX types endpoint.
+type listIXNetworksOptions struct {
+	LocationID int `url:"locationId"`
+}
+
+// ListIXNetworks lists the Internet Exchanges that can be ordered at a location.
+func (svc *IXServiceOp) ListIXNetworks(ctx context.Context, locationID int) ([]*types.IXNetwork, error) {
+	path := "/v2/product/ix/types"
+	url, err := addOptions(svc.Client.BaseURL.JoinPath(path).String(), &listIXNetworksOptions{
+		LocationID: locationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	networksResponse := types.IXNetworksResponse{}
+	_, err = svc.Client.Do(ctx, clientReq, &networksResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return networksResponse.Data, nil
+}
+
+// BuyIX orders a connection from the port given by PortUID to an Internet Exchange. The request is validated before
+// the order is sent.
+func (svc *IXServiceOp) BuyIX(ctx context.Context, req *BuyIXRequest) (*types.IXOrderConfirmation, error) {
+	buyOrder, err := buildIXOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		quote, err := svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+		if err != nil {
+			return nil, err
+		}
+		return &types.IXOrderConfirmation{Quote: quote}, nil
+	}
+
+	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	orderInfo := types.IXOrderResponse{}
+	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if len(orderInfo.Data) == 0 {
+		return nil, newClientError(nil, mega_err.ERR_ORDER_NOT_CONFIRMED)
+	}
+
+	return &types.IXOrderConfirmation{
+		TechnicalServiceUID: orderInfo.Data[0].TechnicalServiceUID,
+	}, nil
+}
+
+// ValidateIXOrder validates an IX order and quotes its price without buying it.
+func (svc *IXServiceOp) ValidateIXOrder(ctx context.Context, req *BuyIXRequest) (*types.OrderQuote, error) {
+	buyOrder, err := buildIXOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+}
+
+func buildIXOrder(req *BuyIXRequest) ([]types.IXOrder, error) {
+	if req.Name == "" {
+		return nil, NewArgError("name", "it must not be empty")
+	}
+	if req.NetworkServiceType == "" {
+		return nil, NewArgError("networkServiceType", "it must name an IX network")
+	}
+	if err := validateIXSettings(req.RateLimit, req.VLAN, req.MACAddress, req.ASN); err != nil {
+		return nil, err
+	}
+
+	buyOrder := []types.IXOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedIXs: []types.IXOrderConfiguration{
+				{
+					Name:               req.Name,
+					NetworkServiceType: req.NetworkServiceType,
+					ASN:                req.ASN,
+					MACAddress:         req.MACAddress,
+					RateLimit:          req.RateLimit,
+					VLAN:               req.VLAN,
+				},
+			},
+		},
+	}
+
+	return buyOrder, nil
+}
+
+func (svc *IXServiceOp) GetIX(ctx context.Context, req *GetIXRequest) (*types.IX, error) {
+	path := "/v2/product/" + req.IXID
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	isError, parsedError := svc.Client.IsErrorResponse(response, &err, 200)
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	ixDetails := types.IXResponse{}
+	unmarshalErr := json.Unmarshal(body, &ixDetails)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return &ixDetails.Data, nil
+}
+
+// UpdateIX updates an IX connection. Zero-valued fields are left unchanged.
+func (svc *IXServiceOp) UpdateIX(ctx context.Context, req *UpdateIXRequest) (*UpdateIXResponse, error) {
+	if err := validateIXUpdate(req); err != nil {
+		return nil, err
+	}
+
+	update := types.IXUpdate{
+		Name:       req.Name,
+		RateLimit:  req.RateLimit,
+		VLAN:       req.VLAN,
+		MACAddress: req.MACAddress,
+		ASN:        req.ASN,
+	}
+
+	path := fmt.Sprintf("/v2/product/%s/%s", types.PRODUCT_IX, req.IXID)
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodPut, url, update)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResponse, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer updateResponse.Body.Close() // nolint
+
+	isResErr, compiledResErr := svc.Client.IsErrorResponse(updateResponse, &err, 200)
+	if isResErr {
+		return nil, compiledResErr
+	}
+
+	return &UpdateIXResponse{
+		IsUpdated: true,
+	}, nil
+}
+
+func (svc *IXServiceOp) DeleteIX(ctx context.Context, req *DeleteIXRequest) (*DeleteIXResponse, error) {
+	_, err := svc.Client.ProductService.DeleteProduct(ctx, &DeleteProductRequest{
+		ProductID: req.IXID,
+		DeleteNow: req.DeleteNow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteIXResponse{
+		IsDeleting: true,
+	}, nil
+}
+
+// WaitForIXProvisioning polls the IX until it reaches one of the target states in the client's WaitOptions
+// (CONFIGURED or LIVE by default). It returns a *ProvisionTimeoutError if the IX is not ready in time.
+func (svc *IXServiceOp) WaitForIXProvisioning(ctx context.Context, ixID string) (bool, error) {
+	err := svc.Client.waitForProvisioning(ctx, "IX", ixID, mega_err.ERR_IX_PROVISION_TIMEOUT_EXCEED, func(ctx context.Context) (string, error) {
+		details, err := svc.GetIX(ctx, &GetIXRequest{
+			IXID: ixID,
+		})
+		if err != nil {
+			return "", err
+		}
+		return details.ProvisioningStatus, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// validateIXUpdate validates only the settings an update changes.
+func validateIXUpdate(req *UpdateIXRequest) error {
+	if req.RateLimit < 0 {
+		return NewArgError("rateLimit", "it must be a positive number of Mbps")
+	}
+	if req.VLAN != 0 {
+		if err := validateIXVLAN(req.VLAN); err != nil {
+			return err
+		}
+	}
+	if req.MACAddress != "" {
+		if err := validateIXMACAddress(req.MACAddress); err != nil {
+			return err
+		}
+	}
+	if req.ASN != 0 {
+		if err := validateIXASN(req.ASN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateIXSettings(rateLimit, vlan int, macAddress string, asn int) error {
+	if rateLimit <= 0 {
+		return NewArgError("rateLimit", "it must be a positive number of Mbps")
+	}
+	if err := validateIXVLAN(vlan); err != nil {
+		return err
+	}
+	if err := validateIXMACAddress(macAddress); err != nil {
+		return err
+	}
+	return validateIXASN(asn)
+}
+
+func validateIXVLAN(vlan int) error {
+	if vlan < 2 || vlan > 4093 {
+		return NewArgError("vlan", fmt.Sprintf("%d is outside the range 2-4093", vlan))
+	}
+	return nil
+}
+
+func validateIXMACAddress(macAddress string) error {
+	hw, err := net.ParseMAC(macAddress)
+	if err != nil || len(hw) != 6 {
+		return NewArgError("macAddress", fmt.Sprintf("%q is not a 48-bit MAC address", macAddress))
+	}
+	return nil
+}
+
+func validateIXASN(asn int) error {
+	if asn <= 0 || int64(asn) > 4294967295 {
+		return NewArgError("asn", fmt.Sprintf("%d is not a valid ASN", asn))
+	}
+	return nil
+}
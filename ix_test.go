@@ -0,0 +1,193 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIXUID = "4f6e2a1d-8c3b-4e5f-9a7d-2b1c0e9f8a7d"
+
+func TestListIXNetworks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/ix/types", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "3", r.URL.Query().Get("locationId"))
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"name":"Sydney IX","asn":58511,"fullName":"Megaport Sydney MegaIX","description":"Sydney IX","groupMetro":"Sydney"},
+			{"name":"Sydney IX Jumbo","asn":58511,"fullName":"Megaport Sydney MegaIX Jumbo","groupMetro":"Sydney"}
+		]}`)
+	})
+
+	networks, err := client.IXService.ListIXNetworks(ctx, 3)
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "Sydney IX", networks[0].Name)
+	assert.Equal(t, 58511, networks[0].ASN)
+}
+
+func TestListProducts_ixAttributeTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"ix-1","productName":"Test IX","productType":"IX","provisioningStatus":"LIVE","attributeTags":{"env":"prod","tier":2}}
+		]}`)
+	})
+
+	products, err := client.ProductService.ListProducts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.EqualValues(t, 2, products[0].(*types.IX).AttributeTags["tier"])
+}
+
+func TestBuyIX(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, "port-1", order[0]["productUid"])
+
+		ix := order[0]["associatedIxs"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "Test IX", ix["productName"])
+		assert.Equal(t, "Sydney IX", ix["networkServiceType"])
+		assert.EqualValues(t, 65000, ix["asn"])
+		assert.Equal(t, "00:11:22:33:44:55", ix["macAddress"])
+		assert.EqualValues(t, 1000, ix["rateLimit"])
+		assert.EqualValues(t, 200, ix["vlan"])
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"technicalServiceUid":%q}]}`, testIXUID)
+	})
+
+	confirmation, err := client.IXService.BuyIX(ctx, &BuyIXRequest{
+		PortUID:            "port-1",
+		Name:               "Test IX",
+		NetworkServiceType: "Sydney IX",
+		ASN:                65000,
+		MACAddress:         "00:11:22:33:44:55",
+		RateLimit:          1000,
+		VLAN:               200,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testIXUID, confirmation.TechnicalServiceUID)
+}
+
+func TestBuyIX_unconfirmed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[]}`)
+	})
+
+	_, err := client.IXService.BuyIX(ctx, &BuyIXRequest{
+		PortUID:            "port-1",
+		Name:               "Test IX",
+		NetworkServiceType: "Sydney IX",
+		ASN:                65000,
+		MACAddress:         "00:11:22:33:44:55",
+		RateLimit:          1000,
+		VLAN:               200,
+	})
+	assert.EqualError(t, err, mega_err.ERR_ORDER_NOT_CONFIRMED)
+}
+
+func TestBuyIX_invalid(t *testing.T) {
+	valid := BuyIXRequest{
+		PortUID:            "port-1",
+		Name:               "Test IX",
+		NetworkServiceType: "Sydney IX",
+		ASN:                65000,
+		MACAddress:         "00:11:22:33:44:55",
+		RateLimit:          1000,
+		VLAN:               200,
+	}
+
+	tests := map[string]func(req *BuyIXRequest){
+		"missing name":         func(req *BuyIXRequest) { req.Name = "" },
+		"missing network":      func(req *BuyIXRequest) { req.NetworkServiceType = "" },
+		"zero rate limit":      func(req *BuyIXRequest) { req.RateLimit = 0 },
+		"reserved vlan":        func(req *BuyIXRequest) { req.VLAN = 1 },
+		"vlan out of range":    func(req *BuyIXRequest) { req.VLAN = 4094 },
+		"malformed mac":        func(req *BuyIXRequest) { req.MACAddress = "00:11:22:33:44" },
+		"64-bit mac":           func(req *BuyIXRequest) { req.MACAddress = "00:11:22:33:44:55:66:77" },
+		"asn out of range":     func(req *BuyIXRequest) { req.ASN = 4294967296 },
+		"asn must be positive": func(req *BuyIXRequest) { req.ASN = 0 },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			req := valid
+			modify(&req)
+			_, err := client.IXService.BuyIX(ctx, &req)
+			assert.IsType(t, &ArgError{}, err)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestIXLifecycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	status := "DEPLOYABLE"
+	mux.HandleFunc("/v2/product/"+testIXUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"productName":"Test IX","productType":"IX","provisioningStatus":%q,"rateLimit":1000,"vlan":200,"macAddress":"00:11:22:33:44:55","asn":65000,"networkServiceType":"Sydney IX","resources":{"bgp_connection":[{"asn":65000,"customer_asn":65000,"isp_asn":58511,"customer_ip_address":"103.26.68.100/23","isp_ip_address":"103.26.68.1"}],"ip_address":[{"address":"103.26.68.100/23","version":4}]}}}`, testIXUID, status)
+		status = "LIVE"
+	})
+	mux.HandleFunc("/v2/product/ix/"+testIXUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		update := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		assert.Equal(t, map[string]interface{}{"name": "Renamed IX", "rateLimit": float64(2000)}, update)
+
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+	mux.HandleFunc("/v3/product/"+testIXUID+"/action/CANCEL", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+
+	client.WaitOptions = WaitOptions{PollInterval: time.Millisecond, Timeout: time.Second}
+
+	ready, err := client.IXService.WaitForIXProvisioning(ctx, testIXUID)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+
+	ix, err := client.IXService.GetIX(ctx, &GetIXRequest{IXID: testIXUID})
+	assert.NoError(t, err)
+	assert.Equal(t, "LIVE", ix.ProvisioningStatus)
+	assert.Equal(t, 65000, ix.ASN)
+	assert.Equal(t, 58511, ix.Resources.BGPConnections[0].ISPASN)
+	assert.Equal(t, "103.26.68.100/23", ix.Resources.IPAddresses[0].Address)
+
+	updated, err := client.IXService.UpdateIX(ctx, &UpdateIXRequest{IXID: testIXUID, Name: "Renamed IX", RateLimit: 2000})
+	assert.NoError(t, err)
+	assert.True(t, updated.IsUpdated)
+
+	_, err = client.IXService.UpdateIX(ctx, &UpdateIXRequest{IXID: testIXUID, MACAddress: "not-a-mac"})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	deleted, err := client.IXService.DeleteIX(ctx, &DeleteIXRequest{IXID: testIXUID})
+	assert.NoError(t, err)
+	assert.True(t, deleted.IsDeleting)
+}
@@ -0,0 +1,242 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// maxLAGPorts is the largest number of ports a LAG can contain, including its primary port.
+const maxLAGPorts = 8
+
+type ListLAGMembersRequest struct {
+	// PortID is the UID of the LAG's primary port.
+	PortID string
+}
+
+type AddLAGPortsRequest struct {
+	// PortID is the UID of the LAG's primary port.
+	PortID string
+	// Count is the number of ports to add. The new ports take their speed, location and term from the primary port.
+	Count int
+	// Name names the new ports. It defaults to the name of the primary port.
+	Name string
+}
+
+type AddLAGPortsResponse struct {
+	TechnicalServiceUIDs []string
+}
+
+type RemoveLAGPortsRequest struct {
+	// PortID is the UID of the LAG's primary port.
+	PortID string
+	// MemberIDs are the UIDs of the ports to remove. The primary port cannot be removed.
+	MemberIDs []string
+	DeleteNow bool
+}
+
+type RemoveLAGPortsResponse struct {
+	IsDeleting bool
+	// RemovedIDs are the UIDs of the ports that were cancelled, in the order they were cancelled.
+	RemovedIDs []string
+}
+
+// PortAggregate is a LAG and the ports in it, or a single port that is not part of a LAG.
+type PortAggregate struct {
+	// LAGID is zero for a port that is not part of a LAG.
+	LAGID int
+	// Primary is the LAG's primary port, or nil if it was not among the grouped ports.
+	Primary *types.Port
+	// Ports are the ports in the aggregate, with the primary port first.
+	Ports []*types.Port
+}
+
+// IsLAG reports whether the aggregate is a LAG rather than a single port.
+func (a *PortAggregate) IsLAG() bool {
+	return a.LAGID != 0
+}
+
+// TotalSpeed returns the combined speed of the aggregate's ports in Mbps.
+func (a *PortAggregate) TotalSpeed() int {
+	total := 0
+	for _, port := range a.Ports {
+		total += port.PortSpeed
+	}
+	return total
+}
+
+// ListLAGMembers lists the active ports in the LAG whose primary port is given by PortID, with the primary port first.
+func (svc *PortServiceOp) ListLAGMembers(ctx context.Context, req *ListLAGMembersRequest) ([]*types.Port, error) {
+	primary, err := svc.GetPort(ctx, &GetPortRequest{
+		PortID: req.PortID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !primary.LAGPrimary || primary.LAGID == 0 {
+		return nil, newClientError(ErrValidation, mega_err.ERR_PORT_NOT_LAG_PRIMARY)
+	}
+
+	ports, err := svc.ListPorts(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []*types.Port{primary}
+	for _, port := range ports {
+		if port.LAGID == primary.LAGID && port.UID != primary.UID {
+			members = append(members, port)
+		}
+	}
+	return members, nil
+}
+
+// AddLAGPorts orders additional ports into an existing LAG.
+func (svc *PortServiceOp) AddLAGPorts(ctx context.Context, req *AddLAGPortsRequest) (*AddLAGPortsResponse, error) {
+	if req.Count < 1 {
+		return nil, NewArgError("count", "at least one port must be added")
+	}
+
+	members, err := svc.ListLAGMembers(ctx, &ListLAGMembersRequest{
+		PortID: req.PortID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(members)+req.Count > maxLAGPorts {
+		return nil, newClientError(ErrValidation, mega_err.ERR_LAG_TOO_MANY_PORTS)
+	}
+
+	primary := members[0]
+	name := req.Name
+	if name == "" {
+		name = primary.Name
+	}
+	term := primary.ContractTermMonths
+	if term == 0 {
+		term = 1
+	}
+
+	buyOrder, err := buildPortOrder(&BuyPortRequest{
+		Name:       name,
+		Term:       term,
+		PortSpeed:  primary.PortSpeed,
+		LocationId: primary.LocationID,
+		Market:     primary.Market,
+		IsLag:      true,
+		LagCount:   req.Count,
+		IsPrivate:  !primary.MarketplaceVisibility,
+	})
+	if err != nil {
+		return nil, err
+	}
+	buyOrder[0].LagID = primary.LAGID
+
+	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	orderInfo := types.PortOrderResponse{}
+	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	uids := make([]string, 0, len(orderInfo.Data))
+	for _, confirmation := range orderInfo.Data {
+		uids = append(uids, confirmation.TechnicalServiceUID)
+	}
+	return &AddLAGPortsResponse{
+		TechnicalServiceUIDs: uids,
+	}, nil
+}
+
+// RemoveLAGPorts cancels member ports of a LAG. Every member is checked before any port is cancelled. Members are
+// cancelled one at a time, so if cancelling one fails, the response is returned with the error and lists the members
+// that were already cancelled.
+func (svc *PortServiceOp) RemoveLAGPorts(ctx context.Context, req *RemoveLAGPortsRequest) (*RemoveLAGPortsResponse, error) {
+	if len(req.MemberIDs) == 0 {
+		return nil, NewArgError("memberIds", "at least one port must be removed")
+	}
+
+	members, err := svc.ListLAGMembers(ctx, &ListLAGMembersRequest{
+		PortID: req.PortID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := map[string]bool{}
+	for _, member := range members[1:] {
+		memberIDs[member.UID] = true
+	}
+	for _, id := range req.MemberIDs {
+		if id == members[0].UID {
+			return nil, newClientError(ErrValidation, mega_err.ERR_LAG_PRIMARY_NOT_REMOVABLE)
+		}
+		if !memberIDs[id] {
+			return nil, newClientError(ErrNotFound, fmt.Sprintf("%s: %s", mega_err.ERR_PORT_NOT_LAG_MEMBER, id))
+		}
+	}
+
+	removed := []string{}
+	for _, id := range req.MemberIDs {
+		_, err := svc.Client.ProductService.DeleteProduct(ctx, &DeleteProductRequest{
+			ProductID: id,
+			DeleteNow: req.DeleteNow,
+		})
+		if err != nil {
+			return &RemoveLAGPortsResponse{
+				IsDeleting: len(removed) > 0,
+				RemovedIDs: removed,
+			}, err
+		}
+		removed = append(removed, id)
+	}
+	return &RemoveLAGPortsResponse{
+		IsDeleting: true,
+		RemovedIDs: removed,
+	}, nil
+}
+
+// ListPortAggregates lists the company's ports that match the filters in req, grouped by LAG.
+func (svc *PortServiceOp) ListPortAggregates(ctx context.Context, req *ListPortsRequest) ([]*PortAggregate, error) {
+	ports, err := svc.ListPorts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return GroupPortsByLAG(ports), nil
+}
+
+// GroupPortsByLAG groups ports into one aggregate per LAG, plus one aggregate for each port that is not part of a
+// LAG. Aggregates are returned in the order their first port appears in ports.
+func GroupPortsByLAG(ports []*types.Port) []*PortAggregate {
+	aggregates := []*PortAggregate{}
+	byLAG := map[int]*PortAggregate{}
+
+	for _, port := range ports {
+		if port.LAGID == 0 {
+			aggregates = append(aggregates, &PortAggregate{Primary: port, Ports: []*types.Port{port}})
+			continue
+		}
+
+		aggregate, ok := byLAG[port.LAGID]
+		if !ok {
+			aggregate = &PortAggregate{LAGID: port.LAGID}
+			byLAG[port.LAGID] = aggregate
+			aggregates = append(aggregates, aggregate)
+		}
+		if port.LAGPrimary {
+			aggregate.Primary = port
+			aggregate.Ports = append([]*types.Port{port}, aggregate.Ports...)
+		} else {
+			aggregate.Ports = append(aggregate.Ports, port)
+		}
+	}
+
+	return aggregates
+}
@@ -0,0 +1,164 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLAGPrimaryJSON = `{"message":"ok","terms":"","data":{"productUid":"port-1","productName":"Sydney Primary","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000,"locationId":3,"market":"AU","lagPrimary":true,"lagId":7,"contractTermMonths":12,"marketplaceVisibility":true}}`
+
+func handleTestLAG() {
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testListPortsJSON)
+	})
+	mux.HandleFunc("/v2/product/port-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testLAGPrimaryJSON)
+	})
+	mux.HandleFunc("/v2/product/port-3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{"productUid":"port-3","productType":"MEGAPORT","provisioningStatus":"CONFIGURED"}}`)
+	})
+}
+
+func TestListLAGMembers(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLAG()
+
+	members, err := client.PortService.ListLAGMembers(ctx, &ListLAGMembersRequest{PortID: "port-1"})
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, "port-1", members[0].UID)
+	assert.Equal(t, "port-2", members[1].UID)
+
+	_, err = client.PortService.ListLAGMembers(ctx, &ListLAGMembersRequest{PortID: "port-3"})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestAddLAGPorts(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLAG()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []types.PortOrder
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, "MEGAPORT", order[0].ProductType)
+		assert.Equal(t, 7, order[0].LagID)
+		assert.Equal(t, 2, order[0].LagPortCount)
+		assert.Equal(t, 10000, order[0].PortSpeed)
+		assert.Equal(t, 3, order[0].LocationID)
+		assert.Equal(t, 12, order[0].Term)
+		assert.Equal(t, "Sydney Primary", order[0].Name)
+
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[{"technicalServiceUid":"port-6"},{"technicalServiceUid":"port-7"}]}`)
+	})
+
+	resp, err := client.PortService.AddLAGPorts(ctx, &AddLAGPortsRequest{PortID: "port-1", Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"port-6", "port-7"}, resp.TechnicalServiceUIDs)
+
+	_, err = client.PortService.AddLAGPorts(ctx, &AddLAGPortsRequest{PortID: "port-1", Count: 7})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = client.PortService.AddLAGPorts(ctx, &AddLAGPortsRequest{PortID: "port-1"})
+	assert.IsType(t, &ArgError{}, err)
+}
+
+func TestRemoveLAGPorts(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLAG()
+
+	cancelled := []string{}
+	mux.HandleFunc("/v3/product/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		cancelled = append(cancelled, r.URL.Path)
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+
+	_, err := client.PortService.RemoveLAGPorts(ctx, &RemoveLAGPortsRequest{PortID: "port-1", MemberIDs: []string{"port-1"}})
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = client.PortService.RemoveLAGPorts(ctx, &RemoveLAGPortsRequest{PortID: "port-1", MemberIDs: []string{"port-2", "port-3"}})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, cancelled)
+
+	resp, err := client.PortService.RemoveLAGPorts(ctx, &RemoveLAGPortsRequest{PortID: "port-1", MemberIDs: []string{"port-2"}, DeleteNow: true})
+	assert.NoError(t, err)
+	assert.True(t, resp.IsDeleting)
+	assert.Equal(t, []string{"port-2"}, resp.RemovedIDs)
+	assert.Equal(t, []string{"/v3/product/port-2/action/CANCEL_NOW"}, cancelled)
+}
+
+func TestRemoveLAGPorts_partialFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"port-1","productType":"MEGAPORT","provisioningStatus":"LIVE","lagPrimary":true,"lagId":7},
+			{"productUid":"port-2","productType":"MEGAPORT","provisioningStatus":"LIVE","lagId":7},
+			{"productUid":"port-8","productType":"MEGAPORT","provisioningStatus":"LIVE","lagId":7}
+		]}`)
+	})
+	mux.HandleFunc("/v2/product/port-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testLAGPrimaryJSON)
+	})
+	mux.HandleFunc("/v3/product/port-2/action/CANCEL", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+	mux.HandleFunc("/v3/product/port-8/action/CANCEL", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message":"Port has active services","terms":"","data":null}`)
+	})
+
+	resp, err := client.PortService.RemoveLAGPorts(ctx, &RemoveLAGPortsRequest{PortID: "port-1", MemberIDs: []string{"port-2", "port-8"}})
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.True(t, resp.IsDeleting)
+	assert.Equal(t, []string{"port-2"}, resp.RemovedIDs)
+}
+
+func TestGroupPortsByLAG(t *testing.T) {
+	ports := []*types.Port{
+		{UID: "member-a", LAGID: 7, PortSpeed: 10000},
+		{UID: "single", PortSpeed: 1000},
+		{UID: "primary", LAGID: 7, LAGPrimary: true, PortSpeed: 10000},
+		{UID: "orphan-member", LAGID: 9, PortSpeed: 100},
+	}
+
+	aggregates := GroupPortsByLAG(ports)
+	assert.Len(t, aggregates, 3)
+
+	assert.True(t, aggregates[0].IsLAG())
+	assert.Equal(t, "primary", aggregates[0].Primary.UID)
+	assert.Equal(t, "primary", aggregates[0].Ports[0].UID)
+	assert.Equal(t, "member-a", aggregates[0].Ports[1].UID)
+	assert.Equal(t, 20000, aggregates[0].TotalSpeed())
+
+	assert.False(t, aggregates[1].IsLAG())
+	assert.Equal(t, "single", aggregates[1].Primary.UID)
+
+	assert.Equal(t, 9, aggregates[2].LAGID)
+	assert.Nil(t, aggregates[2].Primary)
+	assert.Len(t, aggregates[2].Ports, 1)
+}
+
+func TestListPortAggregates(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLAG()
+
+	aggregates, err := client.PortService.ListPortAggregates(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, aggregates, 2)
+	assert.Len(t, aggregates[0].Ports, 2)
+	assert.Equal(t, "port-3", aggregates[1].Primary.UID)
+}
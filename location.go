@@ -3,9 +3,11 @@ package megaport
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/lithammer/fuzzysearch/fuzzy"
 	"github.com/megaport/megaportgo/mega_err"
@@ -14,26 +16,64 @@ import (
 
 type LocationService interface {
 	ListLocations(ctx context.Context) ([]types.Location, error)
+	InvalidateCache()
 	GetLocationByID(ctx context.Context, locationID int) (*types.Location, error)
 	GetLocationByName(ctx context.Context, locationName string) (*types.Location, error)
 	GetLocationByNameFuzzy(ctx context.Context, search string) ([]types.Location, error)
 	ListCountries(ctx context.Context) ([]types.Country, error)
+	ListNetworkRegions(ctx context.Context) ([]types.NetworkRegion, error)
+	GetCountryByCode(ctx context.Context, code string) (*types.Country, error)
 	ListMarketCodes(ctx context.Context) ([]string, error)
 	IsValidMarketCode(ctx context.Context, marketCode string) (*bool, error)
 	FilterLocationsByMarketCode(ctx context.Context, marketCode string, locations *[]types.Location) error
+	ListLocationsSupportingPortSpeed(ctx context.Context, speed int) ([]types.Location, error)
+	ListMCRLocations(ctx context.Context) ([]types.Location, error)
+	ListMVELocations(ctx context.Context, size types.MVEInstanceSize) ([]types.Location, error)
+	FindNearestLocations(ctx context.Context, latitude, longitude float64, n int, filter *LocationSearchFilter) ([]LocationDistance, error)
+	ListLocationsWithinRadius(ctx context.Context, latitude, longitude, radiusKm float64, filter *LocationSearchFilter) ([]LocationDistance, error)
+	SearchLocations(ctx context.Context, req *SearchLocationsRequest) ([]LocationMatch, error)
 }
 
 type LocationServiceOp struct {
 	Client *Client
+
+	// CacheTTL is how long the location and network region lists are reused before they are fetched again. A TTL
+	// of zero or less disables caching.
+	CacheTTL time.Duration
+
+	// NetworkRegion restricts ListCountries, ListMarketCodes and IsValidMarketCode to one network region, e.g.
+	// NetworkRegionMP1. If empty, every region is used.
+	NetworkRegion string
+
+	locations catalogueCache[[]types.Location]
+	regions   catalogueCache[[]types.NetworkRegion]
+	now       func() time.Time
 }
 
 func NewLocationServiceOp(c *Client) *LocationServiceOp {
 	return &LocationServiceOp{
-		Client: c,
+		Client:   c,
+		CacheTTL: DefaultLocationCacheTTL,
+		now:      time.Now,
 	}
 }
 
+// ListLocations lists every Megaport location. The list is cached for CacheTTL, and each call returns a copy of it.
 func (svc *LocationServiceOp) ListLocations(ctx context.Context) ([]types.Location, error) {
+	locations, err := svc.locations.get(ctx, svc.CacheTTL, svc.now, svc.fetchLocations)
+	if err != nil {
+		return nil, err
+	}
+	return cloneLocations(locations), nil
+}
+
+// InvalidateCache discards the cached location and network region lists so the next call fetches them again.
+func (svc *LocationServiceOp) InvalidateCache() {
+	svc.locations.invalidate()
+	svc.regions.invalidate()
+}
+
+func (svc *LocationServiceOp) fetchLocations(ctx context.Context) ([]types.Location, error) {
 	path := "/v2/locations"
 	url := svc.Client.BaseURL.JoinPath(path).String()
 	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
@@ -70,12 +110,7 @@ func (svc *LocationServiceOp) GetLocationByID(ctx context.Context, locationID in
 	if locErr != nil {
 		return nil, locErr
 	}
-	for _, location := range allLocations {
-		if location.ID == locationID {
-			return &location, nil
-		}
-	}
-	return nil, errors.New(mega_err.ERR_LOCATION_NOT_FOUND)
+	return findLocationByID(allLocations, locationID)
 }
 
 func (svc *LocationServiceOp) GetLocationByName(ctx context.Context, locationName string) (*types.Location, error) {
@@ -83,12 +118,7 @@ func (svc *LocationServiceOp) GetLocationByName(ctx context.Context, locationNam
 	if locErr != nil {
 		return nil, locErr
 	}
-	for _, location := range allLocations {
-		if location.Name == locationName {
-			return &location, nil
-		}
-	}
-	return nil, errors.New(mega_err.ERR_LOCATION_NOT_FOUND)
+	return findLocationByName(allLocations, locationName)
 }
 
 func (svc *LocationServiceOp) GetLocationByNameFuzzy(ctx context.Context, search string) ([]types.Location, error) {
@@ -96,22 +126,10 @@ func (svc *LocationServiceOp) GetLocationByNameFuzzy(ctx context.Context, search
 	if err != nil {
 		return nil, err
 	}
-	var matchedLocations []types.Location
-
-	for i := 0; i < len(locations); i++ {
-		if fuzzy.Match(search, locations[i].Name) {
-			matchedLocations = append(matchedLocations, locations[i])
-		}
-	}
-
-	if len(matchedLocations) > 0 {
-		return matchedLocations, nil
-	} else {
-		return matchedLocations, errors.New(mega_err.ERR_NO_MATCHING_LOCATIONS)
-	}
+	return fuzzyMatchLocations(locations, search)
 }
 
-func (svc *LocationServiceOp) ListCountries(ctx context.Context) ([]types.Country, error) {
+func (svc *LocationServiceOp) fetchNetworkRegions(ctx context.Context) ([]types.NetworkRegion, error) {
 	path := "/v2/networkRegions"
 	url := svc.Client.BaseURL.JoinPath(path).String()
 	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
@@ -141,50 +159,133 @@ func (svc *LocationServiceOp) ListCountries(ctx context.Context) ([]types.Countr
 		return nil, unmarshalErr
 	}
 
-	allCountries := make([]types.Country, 0)
+	return countryResponse.Data, nil
+}
 
-	for i := 0; i < len(countryResponse.Data); i++ {
-		if countryResponse.Data[i].NetworkRegion == "MP1" {
-			allCountries = countryResponse.Data[i].Countries
-		}
+// ListCountries lists the countries with Megaport sites. If NetworkRegion is set, only that region's countries are
+// listed; otherwise countries in several regions are listed once with their site counts combined. The network
+// regions are cached for CacheTTL.
+func (svc *LocationServiceOp) ListCountries(ctx context.Context) ([]types.Country, error) {
+	regions, err := svc.ListNetworkRegions(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	return allCountries, nil
+	return countriesInRegion(regions, svc.NetworkRegion), nil
 }
 
+// ListMarketCodes lists the market codes of the countries returned by ListCountries.
 func (svc *LocationServiceOp) ListMarketCodes(ctx context.Context) ([]string, error) {
 	countries, countriesErr := svc.ListCountries(ctx)
 	if countriesErr != nil {
 		return nil, countriesErr
 	}
-	var marketCodes []string
-	for i := 0; i < len(countries); i++ {
-		marketCodes = append(marketCodes, countries[i].Prefix)
-	}
-
-	return marketCodes, nil
+	return marketCodes(countries), nil
 }
 
+// IsValidMarketCode reports whether marketCode is one of the codes returned by ListMarketCodes.
 func (svc *LocationServiceOp) IsValidMarketCode(ctx context.Context, marketCode string) (*bool, error) {
 	marketCodes, err := svc.ListMarketCodes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	found := false
+	return PtrTo(slices.Contains(marketCodes, marketCode)), nil
+}
 
-	for i := 0; i < len(marketCodes); i++ {
-		if marketCodes[i] == marketCode {
-			found = true
+func (svc *LocationServiceOp) FilterLocationsByMarketCode(ctx context.Context, marketCode string, locations *[]types.Location) error {
+	return filterLocationsByMarketCode(ctx, marketCode, locations, svc.IsValidMarketCode)
+}
+
+// ListLocationsSupportingPortSpeed lists the locations where ports of the given speed in Mbps can be ordered.
+func (svc *LocationServiceOp) ListLocationsSupportingPortSpeed(ctx context.Context, speed int) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, func(location *types.Location) bool {
+		return location.Products.SupportsPortSpeed(speed)
+	}), nil
+}
+
+// ListMCRLocations lists the locations where MCRs can be ordered.
+func (svc *LocationServiceOp) ListMCRLocations(ctx context.Context) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, supportsMCR), nil
+}
+
+// ListMVELocations lists the locations where an MVE of the given size can be deployed. An empty size lists every
+// location that offers MVEs.
+func (svc *LocationServiceOp) ListMVELocations(ctx context.Context, size types.MVEInstanceSize) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, func(location *types.Location) bool {
+		return location.Products.SupportsMVESize(size)
+	}), nil
+}
+
+// cloneLocations returns a deep copy of locations, so that changes to the copy, including to a location's address
+// or products, do not affect the original.
+func cloneLocations(locations []types.Location) []types.Location {
+	cloned := make([]types.Location, len(locations))
+	for i, location := range locations {
+		location.Address = maps.Clone(location.Address)
+		location.Products.Megaport = slices.Clone(location.Products.Megaport)
+		location.Products.MCR1 = slices.Clone(location.Products.MCR1)
+		location.Products.MCR2 = slices.Clone(location.Products.MCR2)
+		location.Products.MVE = slices.Clone(location.Products.MVE)
+		for j, mve := range location.Products.MVE {
+			location.Products.MVE[j].Sizes = slices.Clone(mve.Sizes)
+			location.Products.MVE[j].Details = slices.Clone(mve.Details)
 		}
+		cloned[i] = location
 	}
+	return cloned
+}
 
-	return PtrTo(found), nil
+func findLocationByID(locations []types.Location, locationID int) (*types.Location, error) {
+	for _, location := range locations {
+		if location.ID == locationID {
+			return &location, nil
+		}
+	}
+	return nil, newClientError(ErrNotFound, mega_err.ERR_LOCATION_NOT_FOUND)
 }
 
-func (svc *LocationServiceOp) FilterLocationsByMarketCode(ctx context.Context, marketCode string, locations *[]types.Location) error {
+func findLocationByName(locations []types.Location, locationName string) (*types.Location, error) {
+	for _, location := range locations {
+		if location.Name == locationName {
+			return &location, nil
+		}
+	}
+	return nil, newClientError(ErrNotFound, mega_err.ERR_LOCATION_NOT_FOUND)
+}
+
+func fuzzyMatchLocations(locations []types.Location, search string) ([]types.Location, error) {
+	var matchedLocations []types.Location
+
+	for i := 0; i < len(locations); i++ {
+		if fuzzy.Match(search, locations[i].Name) {
+			matchedLocations = append(matchedLocations, locations[i])
+		}
+	}
+
+	if len(matchedLocations) > 0 {
+		return matchedLocations, nil
+	} else {
+		return matchedLocations, newClientError(ErrNotFound, mega_err.ERR_NO_MATCHING_LOCATIONS)
+	}
+}
+
+// filterLocationsByMarketCode filters locations in place to those in marketCode. The list is emptied before the market
+// code is checked, so an invalid market code, or an error checking it, leaves it empty.
+func filterLocationsByMarketCode(ctx context.Context, marketCode string, locations *[]types.Location, isValidMarketCode func(ctx context.Context, marketCode string) (*bool, error)) error {
 	existingLocations := *locations
 	*locations = nil
-	isValid, err := svc.IsValidMarketCode(ctx, marketCode)
+	isValid, err := isValidMarketCode(ctx, marketCode)
 	if err != nil {
 		return err
 	}
@@ -197,3 +298,19 @@ func (svc *LocationServiceOp) FilterLocationsByMarketCode(ctx context.Context, m
 	}
 	return nil
 }
+
+// filterLocations returns the locations for which keep returns true.
+func filterLocations(locations []types.Location, keep func(location *types.Location) bool) []types.Location {
+	filtered := []types.Location{}
+	for i := range locations {
+		if keep(&locations[i]) {
+			filtered = append(filtered, locations[i])
+		}
+	}
+	return filtered
+}
+
+// supportsMCR reports whether MCRs can be ordered at a location. Older locations only set VRouterAvailable.
+func supportsMCR(location *types.Location) bool {
+	return location.VRouterAvailable || location.Products.MCR
+}
@@ -0,0 +1,98 @@
+package megaport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultLocationCacheTTL is how long LocationServiceOp reuses the location and network region lists it has fetched.
+const DefaultLocationCacheTTL = 5 * time.Minute
+
+// SetLocationCacheTTL is a client option for setting how long the location and network region lists are cached. A
+// TTL of zero or less disables caching.
+func SetLocationCacheTTL(ttl time.Duration) ClientOpt {
+	return func(c *Client) error {
+		if svc, ok := c.LocationService.(*LocationServiceOp); ok {
+			svc.CacheTTL = ttl
+		}
+		return nil
+	}
+}
+
+// catalogueCache caches the result of a fetch for a TTL. Concurrent loads of an empty or expired cache share a
+// single fetch.
+type catalogueCache[T any] struct {
+	mu        sync.Mutex
+	value     T
+	fetchedAt time.Time
+	loaded    bool
+	inflight  *catalogueFetch[T]
+}
+
+// catalogueFetch is a fetch in progress. done is closed once value and err are set.
+type catalogueFetch[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// get returns the cached value if it is younger than ttl, and otherwise fetches it. If a fetch is already in
+// progress, get waits for its result instead of starting another. Each caller stops waiting when its own ctx is
+// done, but the fetch itself carries on for the others. Failed fetches are not cached.
+func (c *catalogueCache[T]) get(ctx context.Context, ttl time.Duration, now func() time.Time, fetch func(ctx context.Context) (T, error)) (T, error) {
+	if ttl <= 0 {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if c.loaded && now().Sub(c.fetchedAt) < ttl {
+		value := c.value
+		c.mu.Unlock()
+		return value, nil
+	}
+
+	call := c.inflight
+	if call == nil {
+		call = &catalogueFetch[T]{done: make(chan struct{})}
+		c.inflight = call
+
+		// The fetch is shared, so it must not be cancelled with the context of the caller that happened to start it.
+		go c.fetch(context.WithoutCancel(ctx), call, now, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// fetch runs a shared fetch, caches its result if it succeeded and the cache was not invalidated meanwhile, and
+// then releases its waiters.
+func (c *catalogueCache[T]) fetch(ctx context.Context, call *catalogueFetch[T], now func() time.Time, fetch func(ctx context.Context) (T, error)) {
+	call.value, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	if call.err == nil && c.inflight == call {
+		c.value, c.fetchedAt, c.loaded = call.value, now(), true
+	}
+	if c.inflight == call {
+		c.inflight = nil
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// invalidate empties the cache. A fetch already in progress completes for its callers but its result is not cached.
+func (c *catalogueCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero T
+	c.value, c.fetchedAt, c.loaded = zero, time.Time{}, false
+	c.inflight = nil
+}
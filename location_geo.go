@@ -0,0 +1,141 @@
+package megaport
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0088
+
+// LocationStatusActive is the status of a location that products can be ordered at.
+const LocationStatusActive = "Active"
+
+// LocationSearchFilter narrows a geographic location search. Zero-valued fields do not filter.
+type LocationSearchFilter struct {
+	Market string
+	// ActiveOnly leaves out locations that are still being deployed or are being retired.
+	ActiveOnly bool
+	// MCRCapable keeps only locations where MCRs can be ordered.
+	MCRCapable bool
+}
+
+// LocationDistance is a location and its great-circle distance from the search point.
+type LocationDistance struct {
+	Location   types.Location
+	DistanceKm float64
+}
+
+// FindNearestLocations returns the n locations closest to the given point, nearest first.
+func (svc *LocationServiceOp) FindNearestLocations(ctx context.Context, latitude, longitude float64, n int, filter *LocationSearchFilter) ([]LocationDistance, error) {
+	if err := validateNearestSearch(latitude, longitude, n); err != nil {
+		return nil, err
+	}
+
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nearestLocations(locations, latitude, longitude, n, filter), nil
+}
+
+// ListLocationsWithinRadius returns the locations within radiusKm of the given point, nearest first.
+func (svc *LocationServiceOp) ListLocationsWithinRadius(ctx context.Context, latitude, longitude, radiusKm float64, filter *LocationSearchFilter) ([]LocationDistance, error) {
+	if err := validateRadiusSearch(latitude, longitude, radiusKm); err != nil {
+		return nil, err
+	}
+
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return locationsWithinRadius(locations, latitude, longitude, radiusKm, filter), nil
+}
+
+func nearestLocations(locations []types.Location, latitude, longitude float64, n int, filter *LocationSearchFilter) []LocationDistance {
+	nearest := sortByDistance(locations, latitude, longitude, filter)
+	return nearest[:min(n, len(nearest))]
+}
+
+func locationsWithinRadius(locations []types.Location, latitude, longitude, radiusKm float64, filter *LocationSearchFilter) []LocationDistance {
+	nearest := sortByDistance(locations, latitude, longitude, filter)
+	within := sort.Search(len(nearest), func(i int) bool { return nearest[i].DistanceKm > radiusKm })
+	return nearest[:within]
+}
+
+// sortByDistance returns the locations that match filter with their distance from the given point, nearest first.
+func sortByDistance(locations []types.Location, latitude, longitude float64, filter *LocationSearchFilter) []LocationDistance {
+	if filter == nil {
+		filter = &LocationSearchFilter{}
+	}
+
+	distances := []LocationDistance{}
+	for _, location := range filterLocations(locations, filter.matches) {
+		distances = append(distances, LocationDistance{
+			Location:   location,
+			DistanceKm: greatCircleDistance(latitude, longitude, location.Latitude, location.Longitude),
+		})
+	}
+	sort.SliceStable(distances, func(i, j int) bool { return distances[i].DistanceKm < distances[j].DistanceKm })
+	return distances
+}
+
+func (filter *LocationSearchFilter) matches(location *types.Location) bool {
+	if filter.Market != "" && !strings.EqualFold(location.Market, filter.Market) {
+		return false
+	}
+	if filter.ActiveOnly && !strings.EqualFold(location.Status, LocationStatusActive) {
+		return false
+	}
+	if filter.MCRCapable && !supportsMCR(location) {
+		return false
+	}
+	return true
+}
+
+// greatCircleDistance returns the distance in kilometres between two points given in degrees, using the haversine
+// formula.
+func greatCircleDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func validateNearestSearch(latitude, longitude float64, n int) error {
+	if err := validateCoordinates(latitude, longitude); err != nil {
+		return err
+	}
+	if n < 1 {
+		return NewArgError("n", "at least one location must be requested")
+	}
+	return nil
+}
+
+func validateRadiusSearch(latitude, longitude, radiusKm float64) error {
+	if err := validateCoordinates(latitude, longitude); err != nil {
+		return err
+	}
+	if radiusKm < 0 {
+		return NewArgError("radiusKm", "it must not be negative")
+	}
+	return nil
+}
+
+func validateCoordinates(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return NewArgError("latitude", fmt.Sprintf("%v is outside the range -90 to 90", latitude))
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return NewArgError("longitude", fmt.Sprintf("%v is outside the range -180 to 180", longitude))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package megaport
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// NetworkRegionMP1 is the network region that most Megaport sites are in.
+const NetworkRegionMP1 = "MP1"
+
+// SetLocationNetworkRegion is a client option for restricting country and market code listings to one network
+// region. Use NetworkRegionMP1 to list only MP1 countries and markets.
+func SetLocationNetworkRegion(region string) ClientOpt {
+	return func(c *Client) error {
+		if svc, ok := c.LocationService.(*LocationServiceOp); ok {
+			svc.NetworkRegion = region
+		}
+		return nil
+	}
+}
+
+// ListNetworkRegions lists every network region and the countries and site counts in it. The list is cached for
+// CacheTTL.
+func (svc *LocationServiceOp) ListNetworkRegions(ctx context.Context) ([]types.NetworkRegion, error) {
+	regions, err := svc.regions.get(ctx, svc.CacheTTL, svc.now, svc.fetchNetworkRegions)
+	if err != nil {
+		return nil, err
+	}
+	return cloneNetworkRegions(regions), nil
+}
+
+// GetCountryByCode returns the country with the given ISO 3166-1 alpha-3 code, e.g. "AUS", ignoring case. Its site
+// count covers every network region it is in.
+func (svc *LocationServiceOp) GetCountryByCode(ctx context.Context, code string) (*types.Country, error) {
+	regions, err := svc.ListNetworkRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findCountry(regions, code)
+}
+
+// countriesInRegion returns the countries in region, or in every region if region is empty. A country in several
+// regions is returned once, with its site counts combined.
+func countriesInRegion(regions []types.NetworkRegion, region string) []types.Country {
+	countries := []types.Country{}
+	index := map[string]int{}
+	for _, r := range regions {
+		if region != "" && r.NetworkRegion != region {
+			continue
+		}
+		for _, country := range r.Countries {
+			if i, ok := index[country.Code]; ok {
+				countries[i].SiteCount += country.SiteCount
+				continue
+			}
+			index[country.Code] = len(countries)
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+func findCountry(regions []types.NetworkRegion, code string) (*types.Country, error) {
+	for _, country := range countriesInRegion(regions, "") {
+		if strings.EqualFold(country.Code, code) {
+			return &country, nil
+		}
+	}
+	return nil, newClientError(ErrNotFound, mega_err.ERR_COUNTRY_NOT_FOUND)
+}
+
+// marketCodes returns the distinct market codes of countries in order.
+func marketCodes(countries []types.Country) []string {
+	codes := []string{}
+	for _, country := range countries {
+		if !slices.Contains(codes, country.Prefix) {
+			codes = append(codes, country.Prefix)
+		}
+	}
+	return codes
+}
+
+func cloneNetworkRegions(regions []types.NetworkRegion) []types.NetworkRegion {
+	cloned := make([]types.NetworkRegion, len(regions))
+	for i, region := range regions {
+		cloned[i] = types.NetworkRegion{
+			NetworkRegion: region.NetworkRegion,
+			Countries:     slices.Clone(region.Countries),
+		}
+	}
+	return cloned
+}
@@ -0,0 +1,152 @@
+package megaport
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// SearchLocationsRequest is a ranked fuzzy search over location names, site codes, metros, campuses, countries and
+// addresses.
+type SearchLocationsRequest struct {
+	// Query is matched against each location field ignoring case and accents. Every word of the query must match
+	// at least one field, so "sydney equinix" finds Equinix sites in the Sydney metro.
+	Query string
+	// Limit caps the number of results. Zero returns every match.
+	Limit int
+}
+
+// LocationMatch is a location found by SearchLocations.
+type LocationMatch struct {
+	Location types.Location
+	// Score is the fuzzy match distance summed over the query's words. Lower scores are closer matches, and an
+	// exact match of every word scores 0.
+	Score int
+	// Fields are the location fields that matched, e.g. "name" and "metro".
+	Fields []string
+}
+
+// locationField is a named, searchable field of a location.
+type locationField struct {
+	name  string
+	value string
+}
+
+// SearchLocations returns the locations matching req.Query, best match first.
+func (svc *LocationServiceOp) SearchLocations(ctx context.Context, req *SearchLocationsRequest) ([]LocationMatch, error) {
+	if err := validateSearchRequest(req); err != nil {
+		return nil, err
+	}
+
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankLocations(locations, req)
+}
+
+// rankLocations runs a search over locations, reporting ErrNotFound if nothing matches.
+func rankLocations(locations []types.Location, req *SearchLocationsRequest) ([]LocationMatch, error) {
+	matches := searchLocations(locations, req.Query, req.Limit)
+	if len(matches) == 0 {
+		return nil, newClientError(ErrNotFound, mega_err.ERR_NO_MATCHING_LOCATIONS)
+	}
+	return matches, nil
+}
+
+func validateSearchRequest(req *SearchLocationsRequest) error {
+	if strings.TrimSpace(req.Query) == "" {
+		return NewArgError("query", "it must not be empty")
+	}
+	return nil
+}
+
+// searchLocations ranks locations by how closely they match query and returns at most limit of them, or all of them
+// if limit is zero. Ties are broken by location name.
+func searchLocations(locations []types.Location, query string, limit int) []LocationMatch {
+	terms := strings.Fields(query)
+
+	matches := []LocationMatch{}
+	for _, location := range locations {
+		fields := searchableFields(&location)
+
+		score, matched, ok := matchTerms(terms, fields)
+		if len(terms) > 1 {
+			// The whole query may match one field more closely than its words do separately, e.g. "Equinix SY1".
+			if whole, field, found := bestFieldMatch(query, fields); found && (!ok || whole < score) {
+				score, matched, ok = whole, []string{field}, true
+			}
+		}
+		if ok {
+			matches = append(matches, LocationMatch{Location: location, Score: score, Fields: matched})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score < matches[j].Score
+		}
+		return matches[i].Location.Name < matches[j].Location.Name
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// matchTerms sums the best match distance of each term. It reports false if any term matches no field.
+func matchTerms(terms []string, fields []locationField) (int, []string, bool) {
+	score := 0
+	matched := []string{}
+	for _, term := range terms {
+		distance, field, found := bestFieldMatch(term, fields)
+		if !found {
+			return 0, nil, false
+		}
+		score += distance
+		if !slices.Contains(matched, field) {
+			matched = append(matched, field)
+		}
+	}
+	return score, matched, true
+}
+
+// bestFieldMatch returns the smallest match distance of term across fields and the field it was found in.
+func bestFieldMatch(term string, fields []locationField) (int, string, bool) {
+	best, bestField, found := 0, "", false
+	for _, field := range fields {
+		distance := fuzzy.RankMatchNormalizedFold(term, field.value)
+		if distance >= 0 && (!found || distance < best) {
+			best, bestField, found = distance, field.name, true
+		}
+	}
+	return best, bestField, found
+}
+
+func searchableFields(location *types.Location) []locationField {
+	fields := []locationField{
+		{"name", location.Name},
+		{"siteCode", location.SiteCode},
+		{"metro", location.Metro},
+		{"campus", location.Campus},
+		{"country", location.Country},
+	}
+
+	keys := make([]string, 0, len(location.Address))
+	for key := range location.Address {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields = append(fields, locationField{"address." + key, location.Address[key]})
+	}
+
+	return fields
+}
@@ -0,0 +1,341 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// LocationSnapshotVersion is the version of the snapshot format written by LocationSnapshot.Write.
+const LocationSnapshotVersion = 1
+
+// LocationSnapshot is a point-in-time copy of the location catalogue that can be saved to a file and used offline
+// through SnapshotLocationService.
+type LocationSnapshot struct {
+	Version        int                   `json:"version"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	Locations      []types.Location      `json:"locations"`
+	NetworkRegions []types.NetworkRegion `json:"networkRegions"`
+}
+
+// NewLocationSnapshot captures the locations and network regions, and so the countries, listed by svc.
+func NewLocationSnapshot(ctx context.Context, svc LocationService) (*LocationSnapshot, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := svc.ListNetworkRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LocationSnapshot{
+		Version:        LocationSnapshotVersion,
+		CreatedAt:      time.Now().UTC(),
+		Locations:      locations,
+		NetworkRegions: regions,
+	}, nil
+}
+
+// Write writes the snapshot as indented JSON.
+func (s *LocationSnapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Save writes the snapshot to a file, replacing it if it exists.
+func (s *LocationSnapshot) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadLocationSnapshot reads a snapshot written by LocationSnapshot.Write. Snapshots of an unsupported version are
+// rejected.
+func ReadLocationSnapshot(r io.Reader) (*LocationSnapshot, error) {
+	snapshot := &LocationSnapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Version != LocationSnapshotVersion {
+		return nil, newClientError(ErrValidation, fmt.Sprintf(mega_err.ERR_SNAPSHOT_VERSION, snapshot.Version, LocationSnapshotVersion))
+	}
+	return snapshot, nil
+}
+
+// LoadLocationSnapshot reads a snapshot from a file.
+func LoadLocationSnapshot(path string) (*LocationSnapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadLocationSnapshot(file)
+}
+
+// SnapshotLocationService is a LocationService that answers from a LocationSnapshot instead of the Megaport API.
+type SnapshotLocationService struct {
+	Snapshot *LocationSnapshot
+
+	// NetworkRegion restricts ListCountries, ListMarketCodes and IsValidMarketCode to one network region, e.g.
+	// NetworkRegionMP1. If empty, every region is used.
+	NetworkRegion string
+}
+
+var _ LocationService = (*SnapshotLocationService)(nil)
+
+// NewSnapshotLocationService returns a LocationService backed by snapshot.
+func NewSnapshotLocationService(snapshot *LocationSnapshot) (*SnapshotLocationService, error) {
+	if snapshot == nil {
+		return nil, NewArgError("snapshot", "it must not be nil")
+	}
+	return &SnapshotLocationService{
+		Snapshot: snapshot,
+	}, nil
+}
+
+// NewSnapshotLocationServiceFromFile loads a snapshot from a file and returns a LocationService backed by it.
+func NewSnapshotLocationServiceFromFile(path string) (*SnapshotLocationService, error) {
+	snapshot, err := LoadLocationSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshotLocationService(snapshot)
+}
+
+// ListLocations lists the snapshot's locations. Each call returns a copy of them.
+func (svc *SnapshotLocationService) ListLocations(ctx context.Context) ([]types.Location, error) {
+	if svc.Snapshot == nil {
+		return nil, NewArgError("snapshot", "it must not be nil")
+	}
+	return cloneLocations(svc.Snapshot.Locations), nil
+}
+
+func (svc *SnapshotLocationService) ListNetworkRegions(ctx context.Context) ([]types.NetworkRegion, error) {
+	if svc.Snapshot == nil {
+		return nil, NewArgError("snapshot", "it must not be nil")
+	}
+	return cloneNetworkRegions(svc.Snapshot.NetworkRegions), nil
+}
+
+// InvalidateCache does nothing; a snapshot is never refreshed.
+func (svc *SnapshotLocationService) InvalidateCache() {}
+
+func (svc *SnapshotLocationService) GetLocationByID(ctx context.Context, locationID int) (*types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findLocationByID(locations, locationID)
+}
+
+func (svc *SnapshotLocationService) GetLocationByName(ctx context.Context, locationName string) (*types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findLocationByName(locations, locationName)
+}
+
+func (svc *SnapshotLocationService) GetLocationByNameFuzzy(ctx context.Context, search string) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fuzzyMatchLocations(locations, search)
+}
+
+func (svc *SnapshotLocationService) ListCountries(ctx context.Context) ([]types.Country, error) {
+	regions, err := svc.ListNetworkRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return countriesInRegion(regions, svc.NetworkRegion), nil
+}
+
+func (svc *SnapshotLocationService) GetCountryByCode(ctx context.Context, code string) (*types.Country, error) {
+	regions, err := svc.ListNetworkRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findCountry(regions, code)
+}
+
+func (svc *SnapshotLocationService) ListMarketCodes(ctx context.Context) ([]string, error) {
+	countries, err := svc.ListCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return marketCodes(countries), nil
+}
+
+func (svc *SnapshotLocationService) IsValidMarketCode(ctx context.Context, marketCode string) (*bool, error) {
+	codes, err := svc.ListMarketCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return PtrTo(slices.Contains(codes, marketCode)), nil
+}
+
+func (svc *SnapshotLocationService) FilterLocationsByMarketCode(ctx context.Context, marketCode string, locations *[]types.Location) error {
+	return filterLocationsByMarketCode(ctx, marketCode, locations, svc.IsValidMarketCode)
+}
+
+func (svc *SnapshotLocationService) ListLocationsSupportingPortSpeed(ctx context.Context, speed int) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, func(location *types.Location) bool {
+		return location.Products.SupportsPortSpeed(speed)
+	}), nil
+}
+
+func (svc *SnapshotLocationService) ListMCRLocations(ctx context.Context) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, supportsMCR), nil
+}
+
+func (svc *SnapshotLocationService) ListMVELocations(ctx context.Context, size types.MVEInstanceSize) ([]types.Location, error) {
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocations(locations, func(location *types.Location) bool {
+		return location.Products.SupportsMVESize(size)
+	}), nil
+}
+
+func (svc *SnapshotLocationService) FindNearestLocations(ctx context.Context, latitude, longitude float64, n int, filter *LocationSearchFilter) ([]LocationDistance, error) {
+	if err := validateNearestSearch(latitude, longitude, n); err != nil {
+		return nil, err
+	}
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nearestLocations(locations, latitude, longitude, n, filter), nil
+}
+
+func (svc *SnapshotLocationService) ListLocationsWithinRadius(ctx context.Context, latitude, longitude, radiusKm float64, filter *LocationSearchFilter) ([]LocationDistance, error) {
+	if err := validateRadiusSearch(latitude, longitude, radiusKm); err != nil {
+		return nil, err
+	}
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return locationsWithinRadius(locations, latitude, longitude, radiusKm, filter), nil
+}
+
+func (svc *SnapshotLocationService) SearchLocations(ctx context.Context, req *SearchLocationsRequest) ([]LocationMatch, error) {
+	if err := validateSearchRequest(req); err != nil {
+		return nil, err
+	}
+	locations, err := svc.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rankLocations(locations, req)
+}
+
+// LocationChange is a site that is in both snapshots with different details.
+type LocationChange struct {
+	Before types.Location
+	After  types.Location
+	// Fields are the JSON names of the fields that changed, e.g. "status" and "products".
+	Fields []string
+}
+
+// LocationSnapshotDiff lists the differences between two snapshots. Each list is sorted by location ID.
+type LocationSnapshotDiff struct {
+	// New are sites in the newer snapshot only.
+	New []types.Location
+	// Retired are sites in the older snapshot only.
+	Retired []types.Location
+	Changed []LocationChange
+}
+
+// IsEmpty reports whether the snapshots have the same sites with the same details.
+func (d *LocationSnapshotDiff) IsEmpty() bool {
+	return len(d.New) == 0 && len(d.Retired) == 0 && len(d.Changed) == 0
+}
+
+// DiffLocationSnapshots compares the sites in two snapshots by location ID.
+func DiffLocationSnapshots(older, newer *LocationSnapshot) *LocationSnapshotDiff {
+	diff := &LocationSnapshotDiff{
+		New:     []types.Location{},
+		Retired: []types.Location{},
+		Changed: []LocationChange{},
+	}
+
+	before := map[int]types.Location{}
+	for _, location := range older.Locations {
+		before[location.ID] = location
+	}
+	after := map[int]types.Location{}
+	for _, location := range newer.Locations {
+		after[location.ID] = location
+	}
+
+	for id, location := range after {
+		previous, ok := before[id]
+		if !ok {
+			diff.New = append(diff.New, location)
+			continue
+		}
+		if fields := changedLocationFields(previous, location); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, LocationChange{Before: previous, After: location, Fields: fields})
+		}
+	}
+	for id, location := range before {
+		if _, ok := after[id]; !ok {
+			diff.Retired = append(diff.Retired, location)
+		}
+	}
+
+	sort.Slice(diff.New, func(i, j int) bool { return diff.New[i].ID < diff.New[j].ID })
+	sort.Slice(diff.Retired, func(i, j int) bool { return diff.Retired[i].ID < diff.Retired[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].After.ID < diff.Changed[j].After.ID })
+	return diff
+}
+
+// changedLocationFields returns the JSON names of the fields that differ between two versions of a location, in
+// field order.
+func changedLocationFields(before, after types.Location) []string {
+	fields := []string{}
+	b, a := reflect.ValueOf(before), reflect.ValueOf(after)
+	for i := 0; i < b.NumField(); i++ {
+		if !reflect.DeepEqual(b.Field(i).Interface(), a.Field(i).Interface()) {
+			fields = append(fields, jsonFieldName(b.Type().Field(i)))
+		}
+	}
+	return fields
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
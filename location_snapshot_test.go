@@ -0,0 +1,263 @@
+package megaport
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLocationSnapshot(t *testing.T) *LocationSnapshot {
+	handleTestLocations()
+	handleTestNetworkRegions()
+
+	snapshot, err := NewLocationSnapshot(ctx, client.LocationService)
+	assert.NoError(t, err)
+	return snapshot
+}
+
+func TestLocationSnapshot_roundTrip(t *testing.T) {
+	setup()
+	defer teardown()
+
+	snapshot := newTestLocationSnapshot(t)
+	assert.Equal(t, LocationSnapshotVersion, snapshot.Version)
+	assert.Len(t, snapshot.Locations, 4)
+	assert.Len(t, snapshot.NetworkRegions, 2)
+
+	path := filepath.Join(t.TempDir(), "locations.json")
+	assert.NoError(t, snapshot.Save(path))
+
+	offline, err := NewSnapshotLocationServiceFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Locations, offline.Snapshot.Locations)
+	assert.True(t, snapshot.CreatedAt.Equal(offline.Snapshot.CreatedAt))
+}
+
+func TestReadLocationSnapshot_version(t *testing.T) {
+	_, err := ReadLocationSnapshot(strings.NewReader(`{"version":99,"locations":[]}`))
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = ReadLocationSnapshot(strings.NewReader(`not json`))
+	assert.Error(t, err)
+}
+
+func TestSnapshotLocationService_nilSnapshot(t *testing.T) {
+	_, err := NewSnapshotLocationService(nil)
+	assert.IsType(t, &ArgError{}, err)
+
+	svc := &SnapshotLocationService{}
+	_, err = svc.ListLocations(ctx)
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = svc.GetLocationByID(ctx, 3)
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = svc.IsValidMarketCode(ctx, "AU")
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, svc.FilterLocationsByMarketCode(ctx, "AU", &[]types.Location{}), ErrValidation)
+}
+
+func TestSnapshotLocationService_copiesLocations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	offline, err := NewSnapshotLocationService(newTestLocationSnapshot(t))
+	assert.NoError(t, err)
+
+	locations, err := offline.ListLocations(ctx)
+	assert.NoError(t, err)
+	locations[0].Address["city"] = "Changed"
+	locations[0].Products.Megaport[0] = 400
+
+	location, err := offline.GetLocationByID(ctx, locations[0].ID)
+	assert.NoError(t, err)
+	location.Address["city"] = "Changed"
+
+	assert.Equal(t, "Sydney", offline.Snapshot.Locations[0].Address["city"])
+	assert.Equal(t, []int{1, 10, 100}, offline.Snapshot.Locations[0].Products.Megaport)
+}
+
+func TestSnapshotLocationService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	assert.NoError(t, newTestLocationSnapshot(t).Write(&buf))
+	snapshot, err := ReadLocationSnapshot(&buf)
+	assert.NoError(t, err)
+
+	online := client.LocationService
+	offline, err := NewSnapshotLocationService(snapshot)
+	assert.NoError(t, err)
+
+	// The snapshot answers every query the same way as the API.
+	same := func(want, wantErr interface{}, got, gotErr interface{}) {
+		t.Helper()
+		assert.Equal(t, wantErr, gotErr)
+		assert.Equal(t, want, got)
+	}
+
+	{
+		want, wantErr := online.GetLocationByID(ctx, 4)
+		got, gotErr := offline.GetLocationByID(ctx, 4)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.GetLocationByName(ctx, "Telehouse North")
+		got, gotErr := offline.GetLocationByName(ctx, "Telehouse North")
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.GetLocationByNameFuzzy(ctx, "eqx")
+		got, gotErr := offline.GetLocationByNameFuzzy(ctx, "eqx")
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListCountries(ctx)
+		got, gotErr := offline.ListCountries(ctx)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListMarketCodes(ctx)
+		got, gotErr := offline.ListMarketCodes(ctx)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.GetCountryByCode(ctx, "DEU")
+		got, gotErr := offline.GetCountryByCode(ctx, "DEU")
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListMCRLocations(ctx)
+		got, gotErr := offline.ListMCRLocations(ctx)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListMVELocations(ctx, types.LARGE)
+		got, gotErr := offline.ListMVELocations(ctx, types.LARGE)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListLocationsSupportingPortSpeed(ctx, 100000)
+		got, gotErr := offline.ListLocationsSupportingPortSpeed(ctx, 100000)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		filter := &LocationSearchFilter{ActiveOnly: true}
+		want, wantErr := online.FindNearestLocations(ctx, 48.85, 2.35, 2, filter)
+		got, gotErr := offline.FindNearestLocations(ctx, 48.85, 2.35, 2, filter)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		want, wantErr := online.ListLocationsWithinRadius(ctx, -33.87, 151.21, 800, nil)
+		got, gotErr := offline.ListLocationsWithinRadius(ctx, -33.87, 151.21, 800, nil)
+		same(want, wantErr, got, gotErr)
+	}
+	{
+		req := &SearchLocationsRequest{Query: "sydney equinix"}
+		want, wantErr := online.SearchLocations(ctx, req)
+		got, gotErr := offline.SearchLocations(ctx, req)
+		same(want, wantErr, got, gotErr)
+	}
+
+	locations, _ := offline.ListLocations(ctx)
+	assert.NoError(t, offline.FilterLocationsByMarketCode(ctx, "UK", &locations))
+	assert.Len(t, locations, 1)
+	assert.Equal(t, 9, locations[0].ID)
+
+	offline.NetworkRegion = NetworkRegionMP1
+	valid, err := offline.IsValidMarketCode(ctx, "US")
+	assert.NoError(t, err)
+	assert.False(t, *valid)
+
+	_, err = offline.GetLocationByID(ctx, 1234)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// testLocationServiceContract checks the behaviour every LocationService must share. svc answers from the test
+// locations and network regions; failing returns an error from every lookup.
+func testLocationServiceContract(t *testing.T, svc, failing LocationService) {
+	t.Run("filter by market code", func(t *testing.T) {
+		locations, err := svc.ListLocations(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, svc.FilterLocationsByMarketCode(ctx, "UK", &locations))
+		assert.Len(t, locations, 1)
+		assert.Equal(t, 9, locations[0].ID)
+	})
+
+	t.Run("filter by invalid market code", func(t *testing.T) {
+		locations, err := svc.ListLocations(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, svc.FilterLocationsByMarketCode(ctx, "ZZ", &locations))
+		assert.Nil(t, locations)
+	})
+
+	t.Run("filter by market code error", func(t *testing.T) {
+		locations := []types.Location{{ID: 3, Market: "AU"}}
+		assert.Error(t, failing.FilterLocationsByMarketCode(ctx, "AU", &locations))
+		assert.Nil(t, locations)
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		_, err := svc.GetLocationByID(ctx, 1234)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestLocationServiceContract(t *testing.T) {
+	setup()
+	defer teardown()
+
+	snapshot := newTestLocationSnapshot(t)
+
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"internal error","terms":""}`)
+	}))
+	defer failingServer.Close()
+	failingClient, err := New(nil, SetBaseURL(failingServer.URL))
+	assert.NoError(t, err)
+
+	t.Run("LocationServiceOp", func(t *testing.T) {
+		testLocationServiceContract(t, client.LocationService, failingClient.LocationService)
+	})
+	t.Run("SnapshotLocationService", func(t *testing.T) {
+		offline, err := NewSnapshotLocationService(snapshot)
+		assert.NoError(t, err)
+		testLocationServiceContract(t, offline, &SnapshotLocationService{})
+	})
+}
+
+func TestDiffLocationSnapshots(t *testing.T) {
+	older := &LocationSnapshot{Locations: []types.Location{
+		{ID: 1, Name: "Unchanged", Status: "Active"},
+		{ID: 2, Name: "Retiring", Status: "Active"},
+		{ID: 3, Name: "Upgraded", Status: "Deployment", Products: types.LocationProducts{Megaport: []int{1, 10}}},
+	}}
+	newer := &LocationSnapshot{Locations: []types.Location{
+		{ID: 4, Name: "Brand New", Status: "Deployment"},
+		{ID: 3, Name: "Upgraded", Status: "Active", Products: types.LocationProducts{Megaport: []int{1, 10, 100}}},
+		{ID: 1, Name: "Unchanged", Status: "Active"},
+	}}
+
+	diff := DiffLocationSnapshots(older, newer)
+	assert.False(t, diff.IsEmpty())
+
+	assert.Len(t, diff.New, 1)
+	assert.Equal(t, 4, diff.New[0].ID)
+
+	assert.Len(t, diff.Retired, 1)
+	assert.Equal(t, 2, diff.Retired[0].ID)
+
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, 3, diff.Changed[0].After.ID)
+	assert.Equal(t, []string{"products", "status"}, diff.Changed[0].Fields)
+	assert.Equal(t, "Deployment", diff.Changed[0].Before.Status)
+
+	assert.True(t, DiffLocationSnapshots(newer, newer).IsEmpty())
+}
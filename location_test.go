@@ -3,9 +3,15 @@ package megaport
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -114,3 +120,437 @@ func TestFilterLocationsByMarketCode(t *testing.T) {
 	assert.Less(len(locations), currentCount)
 	assert.Equal("AU", locations[0].Market)
 }
+
+const testLocationsJSON = `{"message":"ok","terms":"","data":[
+	{"id":3,"name":"Equinix SY1","country":"Australia","siteCode":"sy1","networkRegion":"MP1","campus":"Equinix SY1-SY4","latitude":-33.9216,"longitude":151.1877,"market":"AU","metro":"Sydney","vRouterAvailable":true,"status":"Active",
+		"address":{"street":"639 Gardeners Road","suburb":"Mascot","city":"Sydney","state":"NSW","postcode":"2020","country":"Australia"},
+		"products":{"mcr":true,"mcrVersion":2,"megaport":[1,10,100],"mcr2":[1000,2500,5000,10000],"mve":[{"id":1,"vendor":"Fortinet","product":"FortiGate-VM","sizes":["SMALL","MEDIUM","LARGE"]}],"crossConnect":{"available":true,"type":"Standard"}}},
+	{"id":4,"name":"NextDC M1","country":"Australia","siteCode":"mel-nxt1","networkRegion":"MP1","campus":"NextDC M1","latitude":-37.8232,"longitude":144.9406,"market":"AU","metro":"Melbourne","vRouterAvailable":false,"status":"Active",
+		"address":{"street":"826 Lorimer Street","suburb":"Port Melbourne","city":"Melbourne","state":"VIC","postcode":"3207","country":"Australia"},
+		"products":{"megaport":[1,10],"mve":[{"id":2,"vendor":"Cisco","product":"C8000","sizes":["SMALL","MEDIUM","LARGE","X_LARGE_12"]}]}},
+	{"id":9,"name":"Telehouse North","country":"United Kingdom","siteCode":"lon-thn","networkRegion":"MP1","campus":"Telehouse Docklands","latitude":51.5113,"longitude":-0.0016,"market":"UK","metro":"London","vRouterAvailable":true,"status":"Active",
+		"address":{"street":"Coriander Avenue","city":"London","postcode":"E14 2AA","country":"United Kingdom"},
+		"products":{"mcr":true,"mcrVersion":2,"megaport":[1,10],"mcr2":[1000,5000]}},
+	{"id":11,"name":"Equinix FR5","country":"Germany","siteCode":"fra-eq5","networkRegion":"MP1","campus":"Equinix Frankfurt","latitude":50.1196,"longitude":8.7360,"market":"DE","metro":"Frankfurt","vRouterAvailable":false,"status":"Deployment",
+		"address":{"street":"Kleyerstrasse 90","city":"Frankfurt","postcode":"60326","country":"Germany"},
+		"products":{"megaport":[10,100]}}
+]}`
+
+func handleTestLocations() {
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testLocationsJSON)
+	})
+}
+
+func TestLocationProducts(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	location, err := client.LocationService.GetLocationByID(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 10, 100}, location.Products.Megaport)
+	assert.True(t, location.Products.SupportsPortSpeed(10000))
+	assert.False(t, location.Products.SupportsPortSpeed(400000))
+	assert.True(t, location.Products.SupportsMCRSpeed(2500))
+	assert.True(t, location.Products.SupportsMVESize(types.MEDIUM))
+	assert.False(t, location.Products.SupportsMVESize(types.XLARGE))
+	assert.True(t, location.Products.CrossConnect.Available)
+}
+
+func TestListLocationsByProduct(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	ids := func(locations []types.Location, err error) []int {
+		assert.NoError(t, err)
+		ids := []int{}
+		for _, location := range locations {
+			ids = append(ids, location.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int{3, 11}, ids(client.LocationService.ListLocationsSupportingPortSpeed(ctx, 100000)))
+	assert.Equal(t, []int{3, 4, 9}, ids(client.LocationService.ListLocationsSupportingPortSpeed(ctx, 1000)))
+	assert.Equal(t, []int{3, 9}, ids(client.LocationService.ListMCRLocations(ctx)))
+	assert.Equal(t, []int{3, 4}, ids(client.LocationService.ListMVELocations(ctx, "")))
+	assert.Equal(t, []int{4}, ids(client.LocationService.ListMVELocations(ctx, types.XLARGE)))
+}
+
+func TestBuyPort_unsupportedSpeed(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("an unsupported speed must not be ordered")
+	})
+
+	_, err := client.PortService.BuySinglePort(ctx, &BuySinglePortRequest{Name: "Test", Term: 1, PortSpeed: 100000, LocationId: 4})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorContains(t, err, mega_err.ERR_PORT_SPEED_NOT_AVAILABLE)
+
+	_, err = client.PortService.BuySinglePort(ctx, &BuySinglePortRequest{Name: "Test", Term: 1, PortSpeed: 10000, LocationId: 99})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGreatCircleDistance(t *testing.T) {
+	// Sydney to London is roughly 16,990 km.
+	assert.InDelta(t, 16990, greatCircleDistance(-33.8688, 151.2093, 51.5072, -0.1276), 20)
+	assert.Equal(t, 0.0, greatCircleDistance(10, 20, 10, 20))
+}
+
+func TestFindNearestLocations(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	// Sydney CBD.
+	const lat, lon = -33.8688, 151.2093
+
+	nearest, err := client.LocationService.FindNearestLocations(ctx, lat, lon, 2, nil)
+	assert.NoError(t, err)
+	assert.Len(t, nearest, 2)
+	assert.Equal(t, 3, nearest[0].Location.ID)
+	assert.InDelta(t, 6, nearest[0].DistanceKm, 1)
+	assert.Equal(t, 4, nearest[1].Location.ID)
+
+	nearest, err = client.LocationService.FindNearestLocations(ctx, lat, lon, 10, &LocationSearchFilter{MCRCapable: true, Market: "uk"})
+	assert.NoError(t, err)
+	assert.Len(t, nearest, 1)
+	assert.Equal(t, 9, nearest[0].Location.ID)
+
+	// Frankfurt is closest to Berlin but is not yet active.
+	nearest, err = client.LocationService.FindNearestLocations(ctx, 52.52, 13.405, 1, &LocationSearchFilter{ActiveOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, nearest[0].Location.ID)
+
+	_, err = client.LocationService.FindNearestLocations(ctx, 91, 0, 1, nil)
+	assert.IsType(t, &ArgError{}, err)
+	_, err = client.LocationService.FindNearestLocations(ctx, 0, 0, 0, nil)
+	assert.IsType(t, &ArgError{}, err)
+}
+
+func TestListLocationsWithinRadius(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	within, err := client.LocationService.ListLocationsWithinRadius(ctx, -33.8688, 151.2093, 1000, nil)
+	assert.NoError(t, err)
+	assert.Len(t, within, 2)
+	assert.Equal(t, 3, within[0].Location.ID)
+	assert.Equal(t, 4, within[1].Location.ID)
+
+	within, err = client.LocationService.ListLocationsWithinRadius(ctx, -33.8688, 151.2093, 1, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, within)
+
+	_, err = client.LocationService.ListLocationsWithinRadius(ctx, 0, 181, 10, nil)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+const testNetworkRegionsJSON = `{"message":"ok","terms":"","data":[
+	{"networkRegion":"MP1","countries":[
+		{"code":"AUS","name":"Australia","prefix":"AU","siteCount":42},
+		{"code":"GBR","name":"United Kingdom","prefix":"UK","siteCount":18},
+		{"code":"DEU","name":"Germany","prefix":"DE","siteCount":12}
+	]},
+	{"networkRegion":"MP2","countries":[
+		{"code":"USA","name":"United States","prefix":"US","siteCount":3}
+	]}
+]}`
+
+func TestLocationCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var locationFetches, regionFetches int
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		locationFetches++
+		fmt.Fprint(w, testLocationsJSON)
+	})
+	mux.HandleFunc("/v2/networkRegions", func(w http.ResponseWriter, r *http.Request) {
+		regionFetches++
+		fmt.Fprint(w, testNetworkRegionsJSON)
+	})
+
+	svc := client.LocationService.(*LocationServiceOp)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	_, err := svc.GetLocationByID(ctx, 3)
+	assert.NoError(t, err)
+	_, err = svc.GetLocationByName(ctx, "NextDC M1")
+	assert.NoError(t, err)
+	locations, err := svc.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, locationFetches)
+
+	// Callers cannot change the cached list, including the addresses and products of its locations.
+	locations[0].Name = "Changed"
+	locations[0].Address["city"] = "Changed"
+	locations[0].Products.Megaport[0] = 400
+	locations[0].Products.MVE[0].Sizes[0] = types.XLARGE
+	location, _ := svc.GetLocationByID(ctx, 3)
+	assert.Equal(t, "Equinix SY1", location.Name)
+	assert.Equal(t, "Sydney", location.Address["city"])
+	assert.Equal(t, []int{1, 10, 100}, location.Products.Megaport)
+	assert.Equal(t, types.SMALL, location.Products.MVE[0].Sizes[0])
+
+	valid, err := svc.IsValidMarketCode(ctx, "AU")
+	assert.NoError(t, err)
+	assert.True(t, *valid)
+	_, err = svc.ListMarketCodes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, regionFetches)
+
+	now = now.Add(DefaultLocationCacheTTL)
+	_, err = svc.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, locationFetches)
+
+	svc.InvalidateCache()
+	_, err = svc.ListLocations(ctx)
+	assert.NoError(t, err)
+	_, err = svc.ListCountries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, locationFetches)
+	assert.Equal(t, 2, regionFetches)
+}
+
+func TestLocationCache_disabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fetches := 0
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		fmt.Fprint(w, testLocationsJSON)
+	})
+
+	assert.NoError(t, SetLocationCacheTTL(0)(client))
+	for i := 0; i < 3; i++ {
+		_, err := client.LocationService.ListLocations(ctx)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, fetches)
+}
+
+func TestLocationCache_errorsNotCached(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fetches := 0
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if fetches == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"unavailable"}`)
+			return
+		}
+		fmt.Fprint(w, testLocationsJSON)
+	})
+
+	_, err := client.LocationService.ListLocations(ctx)
+	assert.Error(t, err)
+	locations, err := client.LocationService.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, locations, 4)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestLocationCache_singleFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		fmt.Fprint(w, testLocationsJSON)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locations, err := client.LocationService.ListLocations(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, locations, 4)
+		}()
+	}
+
+	// Give every goroutine time to join the fetch in progress before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestLocationCache_leaderCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var fetches atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux.HandleFunc("/v2/locations", func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		fmt.Fprint(w, testLocationsJSON)
+	})
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	leaderErr := make(chan error)
+	go func() {
+		_, err := client.LocationService.ListLocations(leaderCtx)
+		leaderErr <- err
+	}()
+	<-started
+
+	type result struct {
+		locations []types.Location
+		err       error
+	}
+	waiter := make(chan result)
+	go func() {
+		locations, err := client.LocationService.ListLocations(ctx)
+		waiter <- result{locations, err}
+	}()
+
+	// Give the waiter time to join the fetch in progress before the leader gives up on it.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(release)
+	got := <-waiter
+	assert.NoError(t, got.err)
+	assert.Len(t, got.locations, 4)
+
+	_, err := client.LocationService.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestSearchLocations(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	search := func(query string, limit int) []LocationMatch {
+		matches, err := client.LocationService.SearchLocations(ctx, &SearchLocationsRequest{Query: query, Limit: limit})
+		assert.NoError(t, err)
+		return matches
+	}
+
+	matches := search("sydney equinix", 0)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, 3, matches[0].Location.ID)
+	assert.ElementsMatch(t, []string{"metro", "name"}, matches[0].Fields)
+
+	// "Equinix" matches two names equally closely, so they are ordered by name.
+	matches = search("equinix", 0)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, 11, matches[0].Location.ID)
+	assert.Equal(t, 3, matches[1].Location.ID)
+	assert.Equal(t, 4, matches[0].Score)
+
+	// A closer match on a shorter field ranks first.
+	matches = search("melbourne", 0)
+	assert.Equal(t, 4, matches[0].Location.ID)
+	assert.Equal(t, 0, matches[0].Score)
+
+	matches = search("Equinix SY1", 0)
+	assert.Equal(t, 3, matches[0].Location.ID)
+	assert.Equal(t, 0, matches[0].Score)
+
+	matches = search("lon-thn", 0)
+	assert.Equal(t, 9, matches[0].Location.ID)
+	assert.Equal(t, []string{"siteCode"}, matches[0].Fields)
+
+	matches = search("lorimer", 0)
+	assert.Equal(t, 4, matches[0].Location.ID)
+	assert.Equal(t, []string{"address.street"}, matches[0].Fields)
+
+	matches = search("australia", 1)
+	assert.Len(t, matches, 1)
+
+	_, err := client.LocationService.SearchLocations(ctx, &SearchLocationsRequest{Query: "atlantis"})
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = client.LocationService.SearchLocations(ctx, &SearchLocationsRequest{Query: "  "})
+	assert.IsType(t, &ArgError{}, err)
+}
+
+func handleTestNetworkRegions() {
+	mux.HandleFunc("/v2/networkRegions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testNetworkRegionsJSON)
+	})
+}
+
+func TestListNetworkRegions(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestNetworkRegions()
+
+	regions, err := client.LocationService.ListNetworkRegions(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, regions, 2)
+	assert.Equal(t, "MP1", regions[0].NetworkRegion)
+	assert.Equal(t, 72, regions[0].SiteCount())
+	assert.Equal(t, "United States", regions[1].Countries[0].Name)
+
+	country, err := client.LocationService.GetCountryByCode(ctx, "usa")
+	assert.NoError(t, err)
+	assert.Equal(t, "US", country.Prefix)
+	assert.Equal(t, 3, country.SiteCount)
+
+	_, err = client.LocationService.GetCountryByCode(ctx, "XYZ")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMarketCodes_networkRegion(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestNetworkRegions()
+
+	codes, err := client.LocationService.ListMarketCodes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AU", "UK", "DE", "US"}, codes)
+
+	valid, err := client.LocationService.IsValidMarketCode(ctx, "US")
+	assert.NoError(t, err)
+	assert.True(t, *valid)
+
+	assert.NoError(t, SetLocationNetworkRegion(NetworkRegionMP1)(client))
+
+	countries, err := client.LocationService.ListCountries(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, countries, 3)
+
+	valid, err = client.LocationService.IsValidMarketCode(ctx, "US")
+	assert.NoError(t, err)
+	assert.False(t, *valid)
+}
+
+func TestCountriesInRegion_combinesSiteCounts(t *testing.T) {
+	regions := []types.NetworkRegion{
+		{NetworkRegion: "MP1", Countries: []types.Country{{Code: "USA", Prefix: "US", SiteCount: 40}}},
+		{NetworkRegion: "MP2", Countries: []types.Country{{Code: "USA", Prefix: "US", SiteCount: 3}}},
+	}
+
+	countries := countriesInRegion(regions, "")
+	assert.Len(t, countries, 1)
+	assert.Equal(t, 43, countries[0].SiteCount)
+	assert.Equal(t, 40, regions[0].Countries[0].SiteCount)
+
+	assert.Equal(t, 3, countriesInRegion(regions, "MP2")[0].SiteCount)
+}
@@ -0,0 +1,284 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMCRUID = "5b1c7e2f-8a9d-4c3b-a1e2-f3d4c5b6a7e8"
+
+func TestBuyMCR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []types.MCROrder
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, types.PRODUCT_MCR_ORDER, order[0].Type)
+		assert.Equal(t, 2500, order[0].PortSpeed)
+		assert.Equal(t, 64512, order[0].Config.ASN)
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"technicalServiceUid":%q}]}`, testMCRUID)
+	})
+
+	confirmation, err := client.MCRService.BuyMCR(ctx, &BuyMCRRequest{
+		LocationID: 19,
+		Name:       "Test MCR",
+		Term:       1,
+		PortSpeed:  2500,
+		MCRAsn:     64512,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testMCRUID, confirmation.TechnicalServiceUID)
+}
+
+func TestBuyMCR_unconfirmed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[]}`)
+	})
+
+	_, err := client.MCRService.BuyMCR(ctx, &BuyMCRRequest{
+		LocationID: 19,
+		Name:       "Test MCR",
+		Term:       1,
+		PortSpeed:  2500,
+		MCRAsn:     64512,
+	})
+	assert.EqualError(t, err, mega_err.ERR_ORDER_NOT_CONFIRMED)
+}
+
+func TestBuyMCR_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.MCRService.BuyMCR(ctx, &BuyMCRRequest{Term: 2, PortSpeed: 1000})
+	assert.EqualError(t, err, mega_err.ERR_TERM_NOT_VALID)
+
+	_, err = client.MCRService.BuyMCR(ctx, &BuyMCRRequest{Term: 12, PortSpeed: 100})
+	assert.EqualError(t, err, mega_err.ERR_MCR_INVALID_PORT_SPEED)
+}
+
+func TestGetMCR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testMCRUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"productName":"Test MCR","productType":"MCR2","portSpeed":2500,"provisioningStatus":"LIVE","resources":{"virtual_router":{"mcrAsn":64512,"speed":2500}}}}`, testMCRUID)
+	})
+
+	mcr, err := client.MCRService.GetMCR(ctx, &GetMCRRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.Equal(t, testMCRUID, mcr.UID)
+	assert.Equal(t, "Test MCR", mcr.Name)
+	assert.Equal(t, 64512, mcr.Resources.VirtualRouter.ASN)
+}
+
+func TestModifyMCR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/mcr2/"+testMCRUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		update := types.ProductUpdate{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		assert.Equal(t, "Renamed MCR", update.Name)
+
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+
+	resp, err := client.MCRService.ModifyMCR(ctx, &ModifyMCRRequest{MCRID: testMCRUID, Name: "Renamed MCR"})
+	assert.NoError(t, err)
+	assert.True(t, resp.IsUpdated)
+}
+
+func TestDeleteAndRestoreMCR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/product/"+testMCRUID+"/action/CANCEL", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+	mux.HandleFunc("/v2/product/"+testMCRUID+"/action/UN_CANCEL", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+
+	deleteResp, err := client.MCRService.DeleteMCR(ctx, &DeleteMCRRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.True(t, deleteResp.IsDeleting)
+
+	restoreResp, err := client.MCRService.RestoreMCR(ctx, &RestoreMCRRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.True(t, restoreResp.IsRestoring)
+}
+
+func TestLockMCR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	locked := false
+	mux.HandleFunc("/v2/product/"+testMCRUID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"locked":%t}}`, testMCRUID, locked)
+	})
+	mux.HandleFunc("/v2/product/"+testMCRUID+"/lock", func(w http.ResponseWriter, r *http.Request) {
+		locked = r.Method == http.MethodPost
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+
+	lockResp, err := client.MCRService.LockMCR(ctx, &LockMCRRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.True(t, lockResp.IsLocking)
+	assert.True(t, locked)
+
+	_, err = client.MCRService.LockMCR(ctx, &LockMCRRequest{MCRID: testMCRUID})
+	assert.ErrorIs(t, err, ErrAlreadyLocked)
+	assert.EqualError(t, err, mega_err.ERR_MCR_ALREADY_LOCKED)
+
+	unlockResp, err := client.MCRService.UnlockMCR(ctx, &UnlockMCRRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.True(t, unlockResp.IsUnlocking)
+	assert.False(t, locked)
+
+	_, err = client.MCRService.UnlockMCR(ctx, &UnlockMCRRequest{MCRID: testMCRUID})
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.EqualError(t, err, mega_err.ERR_MCR_NOT_LOCKED)
+}
+
+func TestWaitForMCRProvisioning(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testMCRUID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"provisioningStatus":%q}}`, testMCRUID, shared.SERVICE_LIVE)
+	})
+
+	provisioned, err := client.MCRService.WaitForMCRProvisioning(ctx, testMCRUID)
+	assert.NoError(t, err)
+	assert.True(t, provisioned)
+}
+
+func TestMCRPrefixFilterListCRUD(t *testing.T) {
+	setup()
+	defer teardown()
+
+	prefixFilterList := types.MCRPrefixFilterList{
+		Description:   "Customer routes",
+		AddressFamily: types.PREFIX_LIST_IPV4,
+		Entries: []types.MCRPrefixListEntry{
+			{Action: types.PREFIX_LIST_ACTION_PERMIT, Prefix: "10.0.0.0/8", Ge: 16, Le: 24},
+			{Action: types.PREFIX_LIST_ACTION_DENY, Prefix: "0.0.0.0/0"},
+		},
+	}
+
+	mux.HandleFunc("/v2/product/mcr2/"+testMCRUID+"/prefixList", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		created := types.MCRPrefixFilterList{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		assert.Equal(t, prefixFilterList, created)
+
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{"id":42,"description":"Customer routes","addressFamily":"IPv4"}}`)
+	})
+	mux.HandleFunc("/v2/product/mcr2/"+testMCRUID+"/prefixLists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[{"id":42,"description":"Customer routes","addressFamily":"IPv4"}]}`)
+	})
+	mux.HandleFunc("/v2/product/mcr2/"+testMCRUID+"/prefixList/42", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"message":"ok","terms":"","data":{"id":42,"description":"Customer routes","addressFamily":"IPv4","entries":[{"action":"permit","prefix":"10.0.0.0/8","ge":16,"le":24},{"action":"deny","prefix":"0.0.0.0/0"}]}}`)
+		case http.MethodPut, http.MethodDelete:
+			fmt.Fprint(w, `{"message":"ok","terms":""}`)
+		default:
+			t.Errorf("unexpected request method %v", r.Method)
+		}
+	})
+
+	createResp, err := client.MCRService.CreatePrefixFilterList(ctx, &CreateMCRPrefixFilterListRequest{
+		MCRID:            testMCRUID,
+		PrefixFilterList: prefixFilterList,
+	})
+	assert.NoError(t, err)
+	assert.True(t, createResp.IsCreated)
+	assert.Equal(t, 42, createResp.PrefixFilterListID)
+
+	lists, err := client.MCRService.ListMCRPrefixFilterLists(ctx, &ListMCRPrefixFilterListsRequest{MCRID: testMCRUID})
+	assert.NoError(t, err)
+	assert.Len(t, lists, 1)
+	assert.Equal(t, 42, lists[0].Id)
+
+	list, err := client.MCRService.GetMCRPrefixFilterList(ctx, &GetMCRPrefixFilterListRequest{MCRID: testMCRUID, PrefixFilterListID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, list.ID)
+	assert.Equal(t, prefixFilterList.Entries, list.Entries)
+
+	modifyResp, err := client.MCRService.ModifyMCRPrefixFilterList(ctx, &ModifyMCRPrefixFilterListRequest{
+		MCRID:              testMCRUID,
+		PrefixFilterListID: 42,
+		PrefixFilterList:   prefixFilterList,
+	})
+	assert.NoError(t, err)
+	assert.True(t, modifyResp.IsUpdated)
+
+	deleteResp, err := client.MCRService.DeleteMCRPrefixFilterList(ctx, &DeleteMCRPrefixFilterListRequest{MCRID: testMCRUID, PrefixFilterListID: 42})
+	assert.NoError(t, err)
+	assert.True(t, deleteResp.IsDeleted)
+}
+
+func TestValidatePrefixFilterList(t *testing.T) {
+	entry := func(prefix string, ge, le int) types.MCRPrefixListEntry {
+		return types.MCRPrefixListEntry{Action: types.PREFIX_LIST_ACTION_PERMIT, Prefix: prefix, Ge: ge, Le: le}
+	}
+
+	tests := []struct {
+		name          string
+		addressFamily string
+		entries       []types.MCRPrefixListEntry
+		wantErr       bool
+	}{
+		{"valid ipv4", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("10.0.0.0/8", 16, 24)}, false},
+		{"valid ipv6", types.PREFIX_LIST_IPV6, []types.MCRPrefixListEntry{entry("2001:db8::/32", 48, 64)}, false},
+		{"unknown family", "IPv5", []types.MCRPrefixListEntry{entry("10.0.0.0/8", 0, 0)}, true},
+		{"no entries", types.PREFIX_LIST_IPV4, nil, true},
+		{"bad action", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{{Action: "allow", Prefix: "10.0.0.0/8"}}, true},
+		{"bad cidr", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("10.0.0.0/33", 0, 0)}, true},
+		{"mixed family", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("2001:db8::/32", 0, 0)}, true},
+		{"ipv4-mapped ipv6 as ipv4", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("::ffff:10.0.0.0/104", 0, 0)}, true},
+		{"ipv4-mapped ipv6 as ipv6", types.PREFIX_LIST_IPV6, []types.MCRPrefixListEntry{entry("::ffff:10.0.0.0/104", 112, 128)}, true},
+		{"ipv6 le at maximum", types.PREFIX_LIST_IPV6, []types.MCRPrefixListEntry{entry("2001:db8::/32", 0, 128)}, false},
+		{"ge below prefix length", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("10.0.0.0/16", 8, 0)}, true},
+		{"le above maximum", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("10.0.0.0/16", 0, 33)}, true},
+		{"ge above le", types.PREFIX_LIST_IPV4, []types.MCRPrefixListEntry{entry("10.0.0.0/8", 24, 16)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrefixFilterList(&types.MCRPrefixFilterList{
+				Description:   "test",
+				AddressFamily: tt.addressFamily,
+				Entries:       tt.entries,
+			})
+			if tt.wantErr {
+				assert.IsType(t, &ArgError{}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
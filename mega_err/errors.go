@@ -18,6 +18,8 @@ const ERR_PORT_PROVISION_TIMEOUT_EXCEED = "the port took too long to provision"
 const ERR_MCR_PROVISION_TIMEOUT_EXCEED = "the MCR took too long to provision"
 const ERR_MVE_PROVISION_TIMEOUT_EXCEED = "the MVE took too long to provision"
 const ERR_VXC_PROVISION_TIMEOUT_EXCEED = "the VXC took too long to provision"
+const ERR_IX_PROVISION_TIMEOUT_EXCEED = "the IX took too long to provision"
+const ERR_PRODUCT_PROVISION_TIMEOUT_EXCEED = "the product took too long to provision"
 
 const ERR_VXC_NOT_LIVE = "the VXC is not in the expected LIVE state"
 const ERR_VXC_UPDATE_TIMEOUT_EXCEED = "the VXC took longer than 15 minutes to update, and has failed"
@@ -28,9 +30,18 @@ const ERR_TERM_NOT_VALID = "invalid term, valid values are 1, 12, 24, and 36"
 const ERR_PORT_ALREADY_LOCKED = "that port is already locked, cannot lock"
 const ERR_PORT_NOT_LOCKED = "that port not locked, cannot unlock"
 const ERR_PORT_NOT_LIVE = "the port is not in the expected LIVE state"
+const ERR_PORT_SPEED_NOT_AVAILABLE = "ports of that speed are not available at the location"
+const ERR_PORT_NOT_LAG_PRIMARY = "that port is not the primary port of a LAG"
+const ERR_PORT_NOT_LAG_MEMBER = "that port is not a member of the LAG"
+const ERR_LAG_PRIMARY_NOT_REMOVABLE = "the primary port of a LAG cannot be removed from it"
+const ERR_LAG_TOO_MANY_PORTS = "a LAG can have at most 8 ports"
 const ERR_MCR_INVALID_PORT_SPEED = "invalid port speed, valid speeds are 1000, 2500, 5000, and 10000"
 const ERR_MCR_NOT_LIVE = "the MCR is not in the expected LIVE state"
+const ERR_MCR_ALREADY_LOCKED = "that MCR is already locked, cannot lock"
+const ERR_MCR_NOT_LOCKED = "that MCR not locked, cannot unlock"
 const ERR_LOCATION_NOT_FOUND = "unable to find location"
+const ERR_COUNTRY_NOT_FOUND = "unable to find country"
+const ERR_SNAPSHOT_VERSION = "location snapshot version %d is not supported, expected version %d"
 const ERR_NO_MATCHING_LOCATIONS = "unable to find location based on search criteria"
 const ERR_NO_OTP_KEY_DEFINED string = "a one time password key is not defined and we cannot generate a OTP due to this"
 const ERR_PARSING_ERR_RESPONSE = "status code '%v' received from api and there has been an error parsing an error: %s. " +
@@ -38,3 +49,5 @@ const ERR_PARSING_ERR_RESPONSE = "status code '%v' received from api and there h
 const ERR_PARTNER_PORT_NO_RESULTS = "sorry there were no results returned based on the given filters"
 const ERR_SESSION_TOKEN_STILL_EXIST = "it looks like the session was not removed and still exists, logout did not work"
 const ERR_MEGAPORT_URL_NOT_SET = "The variable megaport_url has not been set correctly"
+const ERR_ORDER_NOT_CONFIRMED = "the order response did not confirm any product"
+const ERR_TOKEN_URL_NOT_SET = "no OAuth token endpoint is known for the API host, set one with WithEnvironment"
@@ -0,0 +1,362 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// MVEService is an interface for interfacing with the MVE endpoints
+// of the Megaport API.
+type MVEService interface {
+	BuyMVE(ctx context.Context, req *BuyMVERequest) (*types.MVEOrderConfirmation, error)
+	ValidateMVEOrder(ctx context.Context, req *BuyMVERequest) (*types.OrderQuote, error)
+	ListMVEs(ctx context.Context, req *ListProductsRequest) ([]*types.MVE, error)
+	GetMVE(ctx context.Context, req *GetMVERequest) (*types.MVE, error)
+	ModifyMVE(ctx context.Context, req *ModifyMVERequest) (*ModifyMVEResponse, error)
+	DeleteMVE(ctx context.Context, req *DeleteMVERequest) (*DeleteMVEResponse, error)
+	WaitForMVEProvisioning(ctx context.Context, mveID string) (bool, error)
+}
+
+// MVEServiceOp handles communication with MVE methods of the Megaport API.
+type MVEServiceOp struct {
+	Client *Client
+}
+
+type BuyMVERequest struct {
+	LocationID        int
+	Name              string
+	Term              int
+	VendorConfig      types.VendorConfig
+	NetworkInterfaces []*types.MVENetworkInterface
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+type GetMVERequest struct {
+	MVEID string
+}
+
+type ModifyMVERequest struct {
+	MVEID      string
+	Name       string
+	CostCentre string
+}
+
+type ModifyMVEResponse struct {
+	IsUpdated bool
+}
+
+type DeleteMVERequest struct {
+	MVEID     string
+	DeleteNow bool
+}
+
+type DeleteMVEResponse struct {
+	IsDeleting bool
+}
+
+func NewMVEServiceOp(c *Client) *MVEServiceOp {
+	return &MVEServiceOp{
+		Client: c,
+	}
+}
+
+// BuyMVE orders a Megaport Virtual Edge with the given vendor configuration. The vendor configuration is validated
+// before the order is sent.
+func (svc *MVEServiceOp) BuyMVE(ctx context.Context, req *BuyMVERequest) (*types.MVEOrderConfirmation, error) {
+	buyOrder, err := buildMVEOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		quote, err := svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+		if err != nil {
+			return nil, err
+		}
+		return &types.MVEOrderConfirmation{Quote: quote}, nil
+	}
+
+	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	orderInfo := types.MVEOrderResponse{}
+	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if len(orderInfo.Data) == 0 {
+		return nil, newClientError(nil, mega_err.ERR_ORDER_NOT_CONFIRMED)
+	}
+
+	return &types.MVEOrderConfirmation{
+		TechnicalServiceUID: orderInfo.Data[0].TechnicalServiceUID,
+	}, nil
+}
+
+// ValidateMVEOrder validates an MVE order and quotes its price without buying it.
+func (svc *MVEServiceOp) ValidateMVEOrder(ctx context.Context, req *BuyMVERequest) (*types.OrderQuote, error) {
+	buyOrder, err := buildMVEOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+}
+
+func buildMVEOrder(req *BuyMVERequest) ([]types.MVEOrderConfig, error) {
+	if req.Term != 1 && req.Term != 12 && req.Term != 24 && req.Term != 36 {
+		return nil, newClientError(ErrValidation, mega_err.ERR_TERM_NOT_VALID)
+	}
+	if err := ValidateMVEVendorConfig(req.VendorConfig); err != nil {
+		return nil, err
+	}
+
+	buyOrder := []types.MVEOrderConfig{
+		{
+			LocationID:        req.LocationID,
+			Name:              req.Name,
+			Term:              req.Term,
+			ProductType:       "MVE",
+			NetworkInterfaces: req.NetworkInterfaces,
+			VendorConfig:      req.VendorConfig,
+		},
+	}
+
+	return buyOrder, nil
+}
+
+// ListMVEs lists the company's MVEs that match the filters in req. A nil request returns all active MVEs.
+func (svc *MVEServiceOp) ListMVEs(ctx context.Context, req *ListProductsRequest) ([]*types.MVE, error) {
+	filter := ListProductsRequest{}
+	if req != nil {
+		filter = *req
+	}
+	filter.ProductTypes = []string{types.PRODUCT_MVE}
+
+	products, err := svc.Client.ProductService.ListProducts(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	mves := []*types.MVE{}
+	for _, product := range products {
+		if mve, ok := product.(*types.MVE); ok {
+			mves = append(mves, mve)
+		}
+	}
+	return mves, nil
+}
+
+func (svc *MVEServiceOp) GetMVE(ctx context.Context, req *GetMVERequest) (*types.MVE, error) {
+	path := "/v2/product/" + req.MVEID
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	isError, parsedError := svc.Client.IsErrorResponse(response, &err, 200)
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	mveDetails := types.MVEResponse{}
+	unmarshalErr := json.Unmarshal(body, &mveDetails)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return &mveDetails.Data, nil
+}
+
+// ModifyMVE updates the name and cost centre of an MVE.
+func (svc *MVEServiceOp) ModifyMVE(ctx context.Context, req *ModifyMVERequest) (*ModifyMVEResponse, error) {
+	update := types.MVEUpdate{
+		Name:       req.Name,
+		CostCentre: req.CostCentre,
+	}
+
+	path := fmt.Sprintf("/v2/product/%s/%s", types.PRODUCT_MVE, req.MVEID)
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodPut, url, update)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResponse, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer updateResponse.Body.Close() // nolint
+
+	isResErr, compiledResErr := svc.Client.IsErrorResponse(updateResponse, &err, 200)
+	if isResErr {
+		return nil, compiledResErr
+	}
+
+	return &ModifyMVEResponse{
+		IsUpdated: true,
+	}, nil
+}
+
+func (svc *MVEServiceOp) DeleteMVE(ctx context.Context, req *DeleteMVERequest) (*DeleteMVEResponse, error) {
+	_, err := svc.Client.ProductService.DeleteProduct(ctx, &DeleteProductRequest{
+		ProductID: req.MVEID,
+		DeleteNow: req.DeleteNow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteMVEResponse{
+		IsDeleting: true,
+	}, nil
+}
+
+// WaitForMVEProvisioning polls the MVE until it reaches one of the target states in the client's WaitOptions
+// (CONFIGURED or LIVE by default). It returns a *ProvisionTimeoutError if the MVE is not ready in time.
+func (svc *MVEServiceOp) WaitForMVEProvisioning(ctx context.Context, mveID string) (bool, error) {
+	err := svc.Client.waitForProvisioning(ctx, "MVE", mveID, mega_err.ERR_MVE_PROVISION_TIMEOUT_EXCEED, func(ctx context.Context) (string, error) {
+		details, err := svc.GetMVE(ctx, &GetMVERequest{
+			MVEID: mveID,
+		})
+		if err != nil {
+			return "", err
+		}
+		return details.ProvisioningStatus, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ValidateMVEVendorConfig checks that the fields each vendor requires in order to boot an MVE image are present.
+func ValidateMVEVendorConfig(config types.VendorConfig) error {
+	if config == nil {
+		return NewArgError("vendorConfig", "it must not be nil")
+	}
+
+	type requiredField struct {
+		name  string
+		value string
+	}
+
+	var required []requiredField
+	var imageID int
+	var productSize types.MVEInstanceSize
+
+	switch c := config.(type) {
+	case *types.ArubaConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.ArubaConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required,
+			requiredField{"accountName", c.AccountName},
+			requiredField{"accountKey", c.AccountKey},
+		)
+	case *types.CiscoConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.CiscoConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required, requiredField{"adminSshPublicKey", c.AdminSSHPublicKey})
+	case *types.FortinetConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.FortinetConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required, requiredField{"adminSshPublicKey", c.AdminSSHPublicKey})
+	case *types.PaloAltoConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.PaloAltoConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		if c.AdminSSHPublicKey == "" && c.AdminPasswordHash == "" {
+			return NewArgError("adminSshPublicKey", "either an admin SSH public key or an admin password hash is required")
+		}
+	case *types.VersaConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.VersaConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required,
+			requiredField{"directorAddress", c.DirectorAddress},
+			requiredField{"controllerAddress", c.ControllerAddress},
+			requiredField{"localAuth", c.LocalAuth},
+			requiredField{"remoteAuth", c.RemoteAuth},
+			requiredField{"serialNumber", c.SerialNumber},
+		)
+	case *types.VmwareConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.VmwareConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required,
+			requiredField{"adminSshPublicKey", c.AdminSSHPublicKey},
+			requiredField{"vcoAddress", c.VcoAddress},
+			requiredField{"vcoActivationCode", c.VcoActivationCode},
+		)
+	case *types.SixwindVSRConfig:
+		if c == nil {
+			return NewArgError("vendorConfig", "it must not be nil")
+		}
+		return ValidateMVEVendorConfig(*c)
+	case types.SixwindVSRConfig:
+		imageID, productSize = c.ImageID, c.ProductSize
+		required = append(required, requiredField{"sshPublicKey", c.SSHPublicKey})
+	default:
+		return NewArgError("vendorConfig", fmt.Sprintf("%T is not a supported vendor configuration", config))
+	}
+
+	if imageID <= 0 {
+		return NewArgError("imageId", "it must be a positive image ID")
+	}
+
+	switch productSize {
+	case "", types.SMALL, types.MEDIUM, types.LARGE, types.XLARGE:
+	default:
+		return NewArgError("productSize", fmt.Sprintf("%q is not a valid MVE size", productSize))
+	}
+
+	for _, field := range required {
+		if field.value == "" {
+			return NewArgError(field.name, fmt.Sprintf("it is required for %s MVEs", config.VendorName()))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMVEUID = "9e8d7c6b-5a49-4382-b1a0-f9e8d7c6b5a4"
+
+func TestBuyMVE(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, "MVE", order[0]["productType"])
+
+		vendorConfig := order[0]["vendorConfig"].(map[string]interface{})
+		assert.Equal(t, types.MVE_VENDOR_FORTINET, vendorConfig["vendor"])
+		assert.EqualValues(t, 42, vendorConfig["imageId"])
+		assert.Equal(t, "ssh-rsa AAAA", vendorConfig["adminSshPublicKey"])
+		assert.Equal(t, string(types.MEDIUM), vendorConfig["productSize"])
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"technicalServiceUid":%q}]}`, testMVEUID)
+	})
+
+	confirmation, err := client.MVEService.BuyMVE(ctx, &BuyMVERequest{
+		LocationID: 19,
+		Name:       "Test MVE",
+		Term:       12,
+		VendorConfig: types.FortinetConfig{
+			ImageID:           42,
+			ProductSize:       types.MEDIUM,
+			AdminSSHPublicKey: "ssh-rsa AAAA",
+		},
+		NetworkInterfaces: []*types.MVENetworkInterface{{Description: "Data Plane", VLAN: 0}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testMVEUID, confirmation.TechnicalServiceUID)
+}
+
+func TestBuyMVE_unconfirmed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[]}`)
+	})
+
+	_, err := client.MVEService.BuyMVE(ctx, &BuyMVERequest{
+		LocationID: 19,
+		Name:       "Test MVE",
+		Term:       12,
+		VendorConfig: types.FortinetConfig{
+			ImageID:           42,
+			AdminSSHPublicKey: "ssh-rsa AAAA",
+		},
+	})
+	assert.EqualError(t, err, mega_err.ERR_ORDER_NOT_CONFIRMED)
+}
+
+func TestGetMVE(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testMVEUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"productName":"Test MVE","vendor":"Fortinet","mveSize":"MEDIUM","provisioningStatus":"LIVE","resources":{"virtual_machine":[{"id":1,"cpu_count":4,"image":{"id":42,"vendor":"Fortinet","product":"FortiGate-VM","version":"7.0.14"},"up":true}]}}}`, testMVEUID)
+	})
+
+	mve, err := client.MVEService.GetMVE(ctx, &GetMVERequest{MVEID: testMVEUID})
+	assert.NoError(t, err)
+	assert.Equal(t, testMVEUID, mve.UID)
+	assert.Len(t, mve.Resources.VirtualMachines, 1)
+	assert.Equal(t, 4, mve.Resources.VirtualMachines[0].CPUCount)
+	assert.Equal(t, 42, mve.Resources.VirtualMachines[0].Image.ID)
+}
+
+func TestModifyAndDeleteMVE(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/mve/"+testMVEUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		update := types.MVEUpdate{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		assert.Equal(t, "Renamed MVE", update.Name)
+
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+	mux.HandleFunc("/v3/product/"+testMVEUID+"/action/CANCEL_NOW", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+
+	modifyResp, err := client.MVEService.ModifyMVE(ctx, &ModifyMVERequest{MVEID: testMVEUID, Name: "Renamed MVE"})
+	assert.NoError(t, err)
+	assert.True(t, modifyResp.IsUpdated)
+
+	deleteResp, err := client.MVEService.DeleteMVE(ctx, &DeleteMVERequest{MVEID: testMVEUID, DeleteNow: true})
+	assert.NoError(t, err)
+	assert.True(t, deleteResp.IsDeleting)
+}
+
+func TestWaitForMVEProvisioning(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testMVEUID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"provisioningStatus":%q}}`, testMVEUID, shared.SERVICE_LIVE)
+	})
+
+	provisioned, err := client.MVEService.WaitForMVEProvisioning(ctx, testMVEUID)
+	assert.NoError(t, err)
+	assert.True(t, provisioned)
+}
+
+func TestMVEVendorConfigJSON(t *testing.T) {
+	tests := []struct {
+		config types.VendorConfig
+		want   string
+	}{
+		{
+			types.ArubaConfig{ImageID: 1, ProductSize: types.SMALL, AccountName: "a", AccountKey: "k", SystemTag: "tag"},
+			`{"vendor":"aruba","imageId":1,"productSize":"SMALL","accountName":"a","accountKey":"k","systemTag":"tag"}`,
+		},
+		{
+			types.CiscoConfig{ImageID: 1, AdminSSHPublicKey: "key", ManageLocally: true, FMCIPAddress: "10.0.0.1"},
+			`{"vendor":"cisco","imageId":1,"adminSshPublicKey":"key","manageLocally":true,"fmcIpAddress":"10.0.0.1"}`,
+		},
+		{
+			types.FortinetConfig{ImageID: 1, AdminSSHPublicKey: "key", LicenseData: "licence"},
+			`{"vendor":"fortinet","imageId":1,"adminSshPublicKey":"key","licenseData":"licence"}`,
+		},
+		{
+			&types.PaloAltoConfig{ImageID: 1, AdminPasswordHash: "hash"},
+			`{"vendor":"palo_alto","imageId":1,"adminPasswordHash":"hash"}`,
+		},
+		{
+			types.VersaConfig{ImageID: 1, DirectorAddress: "d", ControllerAddress: "c", LocalAuth: "l", RemoteAuth: "r", SerialNumber: "s"},
+			`{"vendor":"versa","imageId":1,"directorAddress":"d","controllerAddress":"c","localAuth":"l","remoteAuth":"r","serialNumber":"s"}`,
+		},
+		{
+			types.VmwareConfig{ImageID: 1, MVELabel: "label", AdminSSHPublicKey: "key", VcoAddress: "v", VcoActivationCode: "c"},
+			`{"vendor":"vmware","imageId":1,"mveLabel":"label","adminSshPublicKey":"key","vcoAddress":"v","vcoActivationCode":"c"}`,
+		},
+		{
+			types.SixwindVSRConfig{ImageID: 1, SSHPublicKey: "key"},
+			`{"vendor":"6wind","imageId":1,"sshPublicKey":"key"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config.VendorName(), func(t *testing.T) {
+			assert.NoError(t, ValidateMVEVendorConfig(tt.config))
+
+			b, err := json.Marshal(tt.config)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestValidateMVEVendorConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.VendorConfig
+	}{
+		{"nil", nil},
+		{"nil pointer", (*types.CiscoConfig)(nil)},
+		{"missing image", types.CiscoConfig{AdminSSHPublicKey: "key"}},
+		{"missing admin ssh key", types.FortinetConfig{ImageID: 1}},
+		{"missing palo alto credentials", types.PaloAltoConfig{ImageID: 1}},
+		{"missing versa director", types.VersaConfig{ImageID: 1, ControllerAddress: "c", LocalAuth: "l", RemoteAuth: "r", SerialNumber: "s"}},
+		{"missing vco activation code", types.VmwareConfig{ImageID: 1, AdminSSHPublicKey: "key", VcoAddress: "v"}},
+		{"invalid size", types.SixwindVSRConfig{ImageID: 1, SSHPublicKey: "key", ProductSize: "HUGE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.IsType(t, &ArgError{}, ValidateMVEVendorConfig(tt.config))
+		})
+	}
+}
+
+func TestBuyMVE_nilVendorConfig(t *testing.T) {
+	setup()
+	defer teardown()
+
+	req := &BuyMVERequest{
+		LocationID:   19,
+		Name:         "Test MVE",
+		Term:         12,
+		VendorConfig: (*types.PaloAltoConfig)(nil),
+	}
+
+	_, err := client.MVEService.BuyMVE(ctx, req)
+	assert.IsType(t, &ArgError{}, err)
+
+	_, err = client.MVEService.ValidateMVEOrder(ctx, req)
+	assert.IsType(t, &ArgError{}, err)
+}
@@ -0,0 +1,128 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// PartnerService is an interface for interfacing with the Partner Megaport endpoints
+// of the Megaport API.
+type PartnerService interface {
+	ListPartnerMegaports(ctx context.Context) ([]*types.PartnerMegaport, error)
+	FilterPartnerMegaportByProductName(ctx context.Context, partners []*types.PartnerMegaport, productName string, exactMatch bool) ([]*types.PartnerMegaport, error)
+	FilterPartnerMegaportByConnectType(ctx context.Context, partners []*types.PartnerMegaport, connectType string, exactMatch bool) ([]*types.PartnerMegaport, error)
+	FilterPartnerMegaportByCompanyName(ctx context.Context, partners []*types.PartnerMegaport, companyName string, exactMatch bool) ([]*types.PartnerMegaport, error)
+	FilterPartnerMegaportByLocationId(ctx context.Context, partners []*types.PartnerMegaport, locationId int) ([]*types.PartnerMegaport, error)
+	FilterPartnerMegaportByDiversityZone(ctx context.Context, partners []*types.PartnerMegaport, diversityZone string, exactMatch bool) ([]*types.PartnerMegaport, error)
+}
+
+// PartnerServiceOp handles communication with Partner Megaport methods of the Megaport API.
+type PartnerServiceOp struct {
+	Client *Client
+}
+
+func NewPartnerServiceOp(c *Client) *PartnerServiceOp {
+	return &PartnerServiceOp{
+		Client: c,
+	}
+}
+
+// ListPartnerMegaports returns every partner (cloud on-ramp) port available to connect to.
+func (svc *PartnerServiceOp) ListPartnerMegaports(ctx context.Context) ([]*types.PartnerMegaport, error) {
+	path := "/v2/dropdowns/partner/megaports"
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, resErr := svc.Client.Do(ctx, clientReq, nil)
+	if resErr != nil {
+		return nil, resErr
+	}
+	defer response.Body.Close()
+
+	isResErr, compiledResError := svc.Client.IsErrorResponse(response, &resErr, 200)
+	if isResErr {
+		return nil, compiledResError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	partnerResponse := types.PartnerMegaportResponse{}
+	unmarshalErr := json.Unmarshal(body, &partnerResponse)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	partners := make([]*types.PartnerMegaport, 0, len(partnerResponse.Data))
+	for i := range partnerResponse.Data {
+		partners = append(partners, &partnerResponse.Data[i])
+	}
+
+	return partners, nil
+}
+
+func (svc *PartnerServiceOp) FilterPartnerMegaportByProductName(ctx context.Context, partners []*types.PartnerMegaport, productName string, exactMatch bool) ([]*types.PartnerMegaport, error) {
+	return filterPartnerMegaports(partners, func(p *types.PartnerMegaport) bool {
+		return matchPartnerField(p.ProductName, productName, exactMatch)
+	})
+}
+
+// FilterPartnerMegaportByConnectType filters by connect type, e.g. types.CONNECT_TYPE_AWS_VIF.
+func (svc *PartnerServiceOp) FilterPartnerMegaportByConnectType(ctx context.Context, partners []*types.PartnerMegaport, connectType string, exactMatch bool) ([]*types.PartnerMegaport, error) {
+	return filterPartnerMegaports(partners, func(p *types.PartnerMegaport) bool {
+		return matchPartnerField(p.ConnectType, connectType, exactMatch)
+	})
+}
+
+func (svc *PartnerServiceOp) FilterPartnerMegaportByCompanyName(ctx context.Context, partners []*types.PartnerMegaport, companyName string, exactMatch bool) ([]*types.PartnerMegaport, error) {
+	return filterPartnerMegaports(partners, func(p *types.PartnerMegaport) bool {
+		return matchPartnerField(p.CompanyName, companyName, exactMatch)
+	})
+}
+
+func (svc *PartnerServiceOp) FilterPartnerMegaportByLocationId(ctx context.Context, partners []*types.PartnerMegaport, locationId int) ([]*types.PartnerMegaport, error) {
+	return filterPartnerMegaports(partners, func(p *types.PartnerMegaport) bool {
+		return p.LocationId == locationId
+	})
+}
+
+// FilterPartnerMegaportByDiversityZone filters by diversity zone, e.g. "red" or "blue".
+func (svc *PartnerServiceOp) FilterPartnerMegaportByDiversityZone(ctx context.Context, partners []*types.PartnerMegaport, diversityZone string, exactMatch bool) ([]*types.PartnerMegaport, error) {
+	return filterPartnerMegaports(partners, func(p *types.PartnerMegaport) bool {
+		return matchPartnerField(p.DiversityZone, diversityZone, exactMatch)
+	})
+}
+
+func filterPartnerMegaports(partners []*types.PartnerMegaport, keep func(*types.PartnerMegaport) bool) ([]*types.PartnerMegaport, error) {
+	var filtered []*types.PartnerMegaport
+	for _, partner := range partners {
+		if keep(partner) {
+			filtered = append(filtered, partner)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, newClientError(ErrNotFound, mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+	}
+	return filtered, nil
+}
+
+// matchPartnerField compares exactly when exactMatch is set, otherwise it does a case-insensitive substring match.
+func matchPartnerField(value, search string, exactMatch bool) bool {
+	if exactMatch {
+		return value == search
+	}
+	return strings.Contains(strings.ToLower(value), strings.ToLower(search))
+}
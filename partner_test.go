@@ -0,0 +1,77 @@
+package megaport
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPartnerMegaportsJSON = `{"message":"ok","terms":"","data":[
+	{"connectType":"AWS","productUid":"aws-1","title":"Asia Pacific (Sydney) (ap-southeast-2)","companyName":"AWS","diversityZone":"blue","locationId":3,"speed":10000,"rank":1,"vxcPermitted":true},
+	{"connectType":"AWSHC","productUid":"aws-2","title":"Asia Pacific (Sydney) (ap-southeast-2)","companyName":"AWS","diversityZone":"red","locationId":4,"speed":10000,"rank":2,"vxcPermitted":true},
+	{"connectType":"AZURE","productUid":"azure-1","title":"Azure ExpressRoute Sydney","companyName":"Microsoft Azure","diversityZone":"red","locationId":3,"speed":10000,"rank":1,"vxcPermitted":true}
+]}`
+
+func TestListPartnerMegaports(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/dropdowns/partner/megaports", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testPartnerMegaportsJSON)
+	})
+
+	partners, err := client.PartnerService.ListPartnerMegaports(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, partners, 3)
+	assert.Equal(t, "aws-1", partners[0].ProductUID)
+	assert.True(t, partners[2].VXCPermitted)
+}
+
+func TestFilterPartnerMegaports(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/dropdowns/partner/megaports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testPartnerMegaportsJSON)
+	})
+
+	partners, err := client.PartnerService.ListPartnerMegaports(ctx)
+	assert.NoError(t, err)
+
+	aws, err := client.PartnerService.FilterPartnerMegaportByConnectType(ctx, partners, types.CONNECT_TYPE_AWS_VIF, true)
+	assert.NoError(t, err)
+	assert.Len(t, aws, 1)
+	assert.Equal(t, "aws-1", aws[0].ProductUID)
+
+	// A partial connect type match picks up both AWS VIF and hosted connection ports.
+	anyAWS, err := client.PartnerService.FilterPartnerMegaportByConnectType(ctx, partners, "aws", false)
+	assert.NoError(t, err)
+	assert.Len(t, anyAWS, 2)
+
+	azure, err := client.PartnerService.FilterPartnerMegaportByCompanyName(ctx, partners, "azure", false)
+	assert.NoError(t, err)
+	assert.Len(t, azure, 1)
+
+	byLocation, err := client.PartnerService.FilterPartnerMegaportByLocationId(ctx, partners, 3)
+	assert.NoError(t, err)
+	assert.Len(t, byLocation, 2)
+
+	red, err := client.PartnerService.FilterPartnerMegaportByDiversityZone(ctx, byLocation, "red", true)
+	assert.NoError(t, err)
+	assert.Len(t, red, 1)
+	assert.Equal(t, "azure-1", red[0].ProductUID)
+
+	byName, err := client.PartnerService.FilterPartnerMegaportByProductName(ctx, partners, "Sydney", false)
+	assert.NoError(t, err)
+	assert.Len(t, byName, 3)
+
+	none, err := client.PartnerService.FilterPartnerMegaportByConnectType(ctx, partners, types.CONNECT_TYPE_GOOGLE, true)
+	assert.Nil(t, none)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, mega_err.ERR_PARTNER_PORT_NO_RESULTS)
+}
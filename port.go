@@ -3,11 +3,11 @@ package megaport
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+	"regexp"
+	"strings"
 
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/shared"
@@ -21,7 +21,11 @@ type PortService interface {
 	BuyPort(ctx context.Context, req *BuyPortRequest) (*types.PortOrderConfirmation, error)
 	BuySinglePort(ctx context.Context, req *BuySinglePortRequest) (*types.PortOrderConfirmation, error)
 	BuyLAGPort(ctx context.Context, req *BuyLAGPortRequest) (*types.PortOrderConfirmation, error)
-	ListPorts(ctx context.Context) ([]*types.Port, error)
+	ListPorts(ctx context.Context, req *ListPortsRequest) ([]*types.Port, error)
+	ListPortAggregates(ctx context.Context, req *ListPortsRequest) ([]*PortAggregate, error)
+	ListLAGMembers(ctx context.Context, req *ListLAGMembersRequest) ([]*types.Port, error)
+	AddLAGPorts(ctx context.Context, req *AddLAGPortsRequest) (*AddLAGPortsResponse, error)
+	RemoveLAGPorts(ctx context.Context, req *RemoveLAGPortsRequest) (*RemoveLAGPortsResponse, error)
 	GetPort(ctx context.Context, req *GetPortRequest) (*types.Port, error)
 	ModifyPort(ctx context.Context, req *ModifyPortRequest) (*ModifyPortResponse, error)
 	DeletePort(ctx context.Context, req *DeletePortRequest) (*DeletePortResponse, error)
@@ -29,12 +33,7 @@ type PortService interface {
 	LockPort(ctx context.Context, req *LockPortRequest) (*LockPortResponse, error)
 	UnlockPort(ctx context.Context, req *UnlockPortRequest) (*UnlockPortResponse, error)
 	WaitForPortProvisioning(ctx context.Context, portID string) (bool, error)
-}
-
-type ParsedProductsResponse struct {
-	Message string        `json:"message"`
-	Terms   string        `json:"terms"`
-	Data    []interface{} `json:"data"`
+	ValidatePortOrder(ctx context.Context, req *BuyPortRequest) (*types.OrderQuote, error)
 }
 
 // PortServiceOp handles communication with Port methods of the Megaport API.
@@ -51,6 +50,8 @@ type BuyPortRequest struct {
 	IsLag      bool
 	LagCount   int
 	IsPrivate  bool
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
 }
 
 type BuySinglePortRequest struct {
@@ -60,6 +61,8 @@ type BuySinglePortRequest struct {
 	LocationId int
 	Market     string
 	IsPrivate  bool
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
 }
 
 type BuyLAGPortRequest struct {
@@ -70,6 +73,29 @@ type BuyLAGPortRequest struct {
 	Market     string
 	LagCount   int
 	IsPrivate  bool
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+// ListPortsRequest filters the ports returned by ListPorts. As with ListProductsRequest, IncludeInactive is sent to
+// the products endpoint and the remaining filters are applied client-side. Zero-valued fields do not filter, and a
+// nil request returns all active ports.
+type ListPortsRequest struct {
+	// ProvisioningStatuses keeps only ports in one of the given provisioning states.
+	ProvisioningStatuses []string
+	// IncludeInactive keeps decommissioned and cancelled ports, which are left out by default.
+	IncludeInactive bool
+	LocationID      int
+	Market          string
+	// NameContains keeps ports whose name contains the given text, ignoring case.
+	NameContains string
+	NameRegex    *regexp.Regexp
+	PortSpeed    int
+	// IsLAGMember keeps only ports that are (true) or are not (false) part of a LAG.
+	IsLAGMember *bool
+	Locked      *bool
+	// AttributeTags keeps only ports that have every one of the given tags.
+	AttributeTags map[string]string
 }
 
 type GetPortRequest struct {
@@ -127,9 +153,64 @@ func NewPortServiceOp(c *Client) *PortServiceOp {
 }
 
 func (svc *PortServiceOp) BuyPort(ctx context.Context, req *BuyPortRequest) (*types.PortOrderConfirmation, error) {
+	buyOrder, err := buildPortOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkPortSpeed(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		quote, err := svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+		if err != nil {
+			return nil, err
+		}
+		return &types.PortOrderConfirmation{Quote: quote}, nil
+	}
+
+	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
+	if responseError != nil {
+		return nil, responseError
+	}
+	orderInfo := types.PortOrderResponse{}
+	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return &types.PortOrderConfirmation{
+		TechnicalServiceUID: orderInfo.Data[0].TechnicalServiceUID,
+	}, nil
+}
+
+// ValidatePortOrder validates a port order and quotes its price without buying it.
+func (svc *PortServiceOp) ValidatePortOrder(ctx context.Context, req *BuyPortRequest) (*types.OrderQuote, error) {
+	buyOrder, err := buildPortOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkPortSpeed(ctx, req); err != nil {
+		return nil, err
+	}
+	return svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+}
+
+// checkPortSpeed checks that the port's location can deliver the requested speed.
+func (svc *PortServiceOp) checkPortSpeed(ctx context.Context, req *BuyPortRequest) error {
+	location, err := svc.Client.LocationService.GetLocationByID(ctx, req.LocationId)
+	if err != nil {
+		return err
+	}
+	if !location.Products.SupportsPortSpeed(req.PortSpeed) {
+		return newClientError(ErrValidation, fmt.Sprintf("%s: %d Mbps at %s", mega_err.ERR_PORT_SPEED_NOT_AVAILABLE, req.PortSpeed, location.Name))
+	}
+	return nil
+}
+
+func buildPortOrder(req *BuyPortRequest) ([]types.PortOrder, error) {
 	var buyOrder []types.PortOrder
 	if req.Term != 1 && req.Term != 12 && req.Term != 24 && req.Term != 36 {
-		return nil, errors.New(mega_err.ERR_TERM_NOT_VALID)
+		return nil, newClientError(ErrValidation, mega_err.ERR_TERM_NOT_VALID)
 	}
 	if req.IsLag {
 		buyOrder = []types.PortOrder{
@@ -161,20 +242,7 @@ func (svc *PortServiceOp) BuyPort(ctx context.Context, req *BuyPortRequest) (*ty
 			},
 		}
 	}
-
-	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
-	if responseError != nil {
-		fmt.Println("Your product did not order properly :(")
-		return nil, responseError
-	}
-	orderInfo := types.PortOrderResponse{}
-	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
-	if unmarshalErr != nil {
-		return nil, unmarshalErr
-	}
-	return &types.PortOrderConfirmation{
-		TechnicalServiceUID: orderInfo.Data[0].TechnicalServiceUID,
-	}, nil
+	return buyOrder, nil
 }
 
 func (svc *PortServiceOp) BuySinglePort(ctx context.Context, req *BuySinglePortRequest) (*types.PortOrderConfirmation, error) {
@@ -187,6 +255,7 @@ func (svc *PortServiceOp) BuySinglePort(ctx context.Context, req *BuySinglePortR
 		IsLag:      false,
 		LagCount:   0,
 		IsPrivate:  req.IsPrivate,
+		DryRun:     req.DryRun,
 	})
 }
 
@@ -200,65 +269,60 @@ func (svc *PortServiceOp) BuyLAGPort(ctx context.Context, req *BuyLAGPortRequest
 		IsLag:      true,
 		LagCount:   req.LagCount,
 		IsPrivate:  req.IsPrivate,
+		DryRun:     req.DryRun,
 	})
 }
 
-func (svc *PortServiceOp) ListPorts(ctx context.Context) ([]*types.Port, error) {
-	path := "/v2/products"
-	url := svc.Client.BaseURL.JoinPath(path).String()
-	req, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+// ListPorts lists the company's ports that match the filters in req.
+func (svc *PortServiceOp) ListPorts(ctx context.Context, req *ListPortsRequest) ([]*types.Port, error) {
+	if req == nil {
+		req = &ListPortsRequest{}
 	}
-	response, err := svc.Client.Do(ctx, req, nil)
+
+	products, err := svc.Client.ProductService.ListProducts(ctx, &ListProductsRequest{
+		ProductTypes:         []string{types.PRODUCT_MEGAPORT},
+		ProvisioningStatuses: req.ProvisioningStatuses,
+		IncludeInactive:      req.IncludeInactive,
+		NameContains:         req.NameContains,
+		NameRegex:            req.NameRegex,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close() // nolint
-
-	isError, parsedError := svc.Client.IsErrorResponse(response, &err, 200)
-	if isError {
-		return nil, parsedError
+	ports := []*types.Port{}
+	for _, product := range products {
+		if port, ok := product.(*types.Port); ok && req.matches(port) {
+			ports = append(ports, port)
+		}
 	}
-	body, fileErr := io.ReadAll(response.Body)
+	return ports, nil
+}
 
-	if fileErr != nil {
-		return nil, fileErr
+// matches applies the port filters that ListProductsRequest does not.
+func (req *ListPortsRequest) matches(port *types.Port) bool {
+	if req.LocationID != 0 && port.LocationID != req.LocationID {
+		return false
 	}
-
-	parsed := ParsedProductsResponse{}
-
-	unmarshalErr := json.Unmarshal(body, &parsed)
-
-	if unmarshalErr != nil {
-		return nil, unmarshalErr
+	if req.Market != "" && !strings.EqualFold(port.Market, req.Market) {
+		return false
 	}
-
-	ports := []*types.Port{}
-
-	for _, unmarshaledData := range parsed.Data {
-		// The products query response will likely contain non-port objects.  As a result
-		// we need to initially Unmarshal as ParsedProductsResponse so that we may iterate
-		// over the entries in Data then re-Marshal those entries so that we may Unmarshal
-		// them as Port (and `continue` where that doesn't work).  We could write a custom
-		// deserializer to avoid this but that is a lot of work for a performance
-		// optimization which is likely irrelevant in practice.
-		// Unfortunately I know of no better (maintainable) method of making this work.
-		remarshaled, err := json.Marshal(unmarshaledData)
-		if err != nil {
-			svc.Client.Logger.Debug(fmt.Sprintf("Could not remarshal %v as port.", err.Error()))
-			continue
-		}
-		port := types.Port{}
-		unmarshalErr = json.Unmarshal(remarshaled, &port)
-		if unmarshalErr != nil {
-			svc.Client.Logger.Debug(fmt.Sprintf("Could not unmarshal %v as port.", unmarshalErr.Error()))
-			continue
+	if req.PortSpeed != 0 && port.PortSpeed != req.PortSpeed {
+		return false
+	}
+	if req.IsLAGMember != nil && (port.LAGPrimary || port.LAGID != 0) != *req.IsLAGMember {
+		return false
+	}
+	if req.Locked != nil && port.Locked != *req.Locked {
+		return false
+	}
+	for key, value := range req.AttributeTags {
+		tag, ok := port.AttributeTags[key]
+		if !ok || fmt.Sprint(tag) != value {
+			return false
 		}
-		ports = append(ports, &port)
 	}
-	return ports, nil
+	return true
 }
 
 func (svc *PortServiceOp) GetPort(ctx context.Context, req *GetPortRequest) (*types.Port, error) {
@@ -354,7 +418,7 @@ func (svc *PortServiceOp) LockPort(ctx context.Context, req *LockPortRequest) (*
 		}
 		return &LockPortResponse{IsLocking: true}, nil
 	} else {
-		return nil, errors.New(mega_err.ERR_PORT_ALREADY_LOCKED)
+		return nil, newClientError(ErrAlreadyLocked, mega_err.ERR_PORT_ALREADY_LOCKED)
 	}
 }
 
@@ -375,28 +439,24 @@ func (svc *PortServiceOp) UnlockPort(ctx context.Context, req *UnlockPortRequest
 		}
 		return &UnlockPortResponse{IsUnlocking: true}, nil
 	} else {
-		return nil, errors.New(mega_err.ERR_PORT_NOT_LOCKED)
+		return nil, newClientError(ErrConflict, mega_err.ERR_PORT_NOT_LOCKED)
 	}
 }
 
+// WaitForPortProvisioning polls the port until it reaches one of the target states in the client's WaitOptions
+// (CONFIGURED or LIVE by default). It returns a *ProvisionTimeoutError if the port is not ready in time.
 func (svc *PortServiceOp) WaitForPortProvisioning(ctx context.Context, portId string) (bool, error) {
-	// Try for ~5mins.
-	for i := 0; i < 30; i++ {
+	err := svc.Client.waitForProvisioning(ctx, "Port", portId, mega_err.ERR_PORT_PROVISION_TIMEOUT_EXCEED, func(ctx context.Context) (string, error) {
 		details, err := svc.GetPort(ctx, &GetPortRequest{
 			PortID: portId,
 		})
 		if err != nil {
-			return false, err
+			return "", err
 		}
-
-		if details.ProvisioningStatus == shared.SERVICE_LIVE {
-			return true, nil
-		}
-
-		// Wrong status, wait a bit and try again.
-		svc.Client.Logger.Debug(fmt.Sprintf("Port status is currently %q - waiting", details.ProvisioningStatus), "status", details.ProvisioningStatus, "port_id", portId)
-		time.Sleep(10 * time.Second)
+		return details.ProvisioningStatus, nil
+	})
+	if err != nil {
+		return false, err
 	}
-
-	return false, errors.New(mega_err.ERR_PORT_PROVISION_TIMEOUT_EXCEED)
+	return true, nil
 }
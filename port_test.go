@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/megaport/megaportgo/mega_err"
@@ -26,7 +28,7 @@ func TestSinglePort(t *testing.T) {
 		t.FailNow()
 	}
 
-	portsListInitial, err := megaportClient.PortService.ListPorts(ctx)
+	portsListInitial, err := megaportClient.PortService.ListPorts(ctx, &ListPortsRequest{IncludeInactive: true})
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
@@ -48,7 +50,7 @@ func TestSinglePort(t *testing.T) {
 		t.FailNow()
 	}
 
-	portsListPostCreate, err := megaportClient.PortService.ListPorts(ctx)
+	portsListPostCreate, err := megaportClient.PortService.ListPorts(ctx, &ListPortsRequest{IncludeInactive: true})
 	if err != nil {
 		megaportClient.Logger.Debug("Failed to get ports list", "error", err)
 		t.FailNow()
@@ -95,7 +97,7 @@ func TestLAGPort(t *testing.T) {
 		t.FailNow()
 	}
 
-	portsListInitial, err := megaportClient.PortService.ListPorts(ctx)
+	portsListInitial, err := megaportClient.PortService.ListPorts(ctx, &ListPortsRequest{IncludeInactive: true})
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
@@ -114,7 +116,7 @@ func TestLAGPort(t *testing.T) {
 		t.FailNow()
 	}
 
-	portsListPostCreate, err := megaportClient.PortService.ListPorts(ctx)
+	portsListPostCreate, err := megaportClient.PortService.ListPorts(ctx, &ListPortsRequest{IncludeInactive: true})
 	if err != nil {
 		megaportClient.Logger.Error("Failed to get ports list", "error", err)
 		t.FailNow()
@@ -273,3 +275,54 @@ func testLockPort(c *Client, ctx context.Context, portId string, t *testing.T) {
 	assert.Nil(t, unlockResp)
 	assert.Error(t, errors.New(mega_err.ERR_PORT_NOT_LOCKED), unlockErr)
 }
+
+const testListPortsJSON = `{"message":"ok","terms":"","data":[
+	{"productUid":"port-1","productName":"Sydney Primary","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000,"locationId":3,"market":"AU","lagPrimary":true,"lagId":7,"locked":true,"attributeTags":{"env":"prod"}},
+	{"productUid":"port-2","productName":"Sydney Secondary","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000,"locationId":3,"market":"AU","lagId":7,"attributeTags":{"env":"prod"}},
+	{"productUid":"port-3","productName":"Melbourne Test","productType":"MEGAPORT","provisioningStatus":"CONFIGURED","portSpeed":1000,"locationId":4,"market":"AU","attributeTags":{"env":"test"}},
+	{"productUid":"port-4","productName":"London Old","productType":"MEGAPORT","provisioningStatus":"DECOMMISSIONED","portSpeed":1000,"locationId":9,"market":"UK"},
+	{"productUid":"port-5","productName":"Frankfurt Cancelled","productType":"MEGAPORT","provisioningStatus":"CANCELLED","portSpeed":100,"locationId":11,"market":"DE"},
+	{"productUid":"mcr-1","productName":"Sydney MCR","productType":"MCR2","provisioningStatus":"LIVE","locationId":3,"market":"AU"}
+]}`
+
+func TestListPorts_filters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testListPortsJSON)
+	})
+
+	tests := []struct {
+		name string
+		req  *ListPortsRequest
+		want []string
+	}{
+		{"default excludes inactive", nil, []string{"port-1", "port-2", "port-3"}},
+		{"include inactive", &ListPortsRequest{IncludeInactive: true}, []string{"port-1", "port-2", "port-3", "port-4", "port-5"}},
+		{"status", &ListPortsRequest{ProvisioningStatuses: []string{shared.SERVICE_CONFIGURED}}, []string{"port-3"}},
+		{"location", &ListPortsRequest{LocationID: 3}, []string{"port-1", "port-2"}},
+		{"market", &ListPortsRequest{Market: "uk", IncludeInactive: true}, []string{"port-4"}},
+		{"name contains", &ListPortsRequest{NameContains: "sydney"}, []string{"port-1", "port-2"}},
+		{"name regex", &ListPortsRequest{NameRegex: regexp.MustCompile(`^(Melbourne|London)`), IncludeInactive: true}, []string{"port-3", "port-4"}},
+		{"speed", &ListPortsRequest{PortSpeed: 1000}, []string{"port-3"}},
+		{"lag members", &ListPortsRequest{IsLAGMember: PtrTo(true)}, []string{"port-1", "port-2"}},
+		{"not lag members", &ListPortsRequest{IsLAGMember: PtrTo(false)}, []string{"port-3"}},
+		{"locked", &ListPortsRequest{Locked: PtrTo(true)}, []string{"port-1"}},
+		{"attribute tags", &ListPortsRequest{AttributeTags: map[string]string{"env": "prod"}}, []string{"port-1", "port-2"}},
+		{"combined", &ListPortsRequest{LocationID: 3, Locked: PtrTo(false)}, []string{"port-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ports, err := client.PortService.ListPorts(ctx, tt.req)
+			assert.NoError(t, err)
+
+			uids := []string{}
+			for _, port := range ports {
+				uids = append(uids, port.UID)
+			}
+			assert.Equal(t, tt.want, uids)
+		})
+	}
+}
@@ -2,17 +2,24 @@ package megaport
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/megaport/megaportgo/mega_err"
 	"github.com/megaport/megaportgo/types"
 )
 
 type ProductService interface {
+	ListProducts(ctx context.Context, req *ListProductsRequest) ([]types.Product, error)
+	BuildTopology(ctx context.Context) (*Topology, error)
 	ExecuteOrder(ctx context.Context, requestBody interface{}) (*[]byte, error)
+	ValidateOrder(ctx context.Context, requestBody interface{}) (*types.OrderQuote, error)
 	ModifyProduct(ctx context.Context, req *ModifyProductRequest) (*ModifyProductResponse, error)
 	DeleteProduct(ctx context.Context, req *DeleteProductRequest) (*DeleteProductResponse, error)
 	RestoreProduct(ctx context.Context, req *RestoreProductRequest) (*RestoreProductResponse, error)
@@ -56,6 +63,26 @@ type ManageProductLockRequest struct {
 
 type ManageProductLockResponse struct{}
 
+// ListProductsRequest filters the products returned by ListProducts, ListMCRs and ListMVEs. IncludeInactive is sent
+// to the products endpoint, which takes no other filter parameters, so the remaining filters are applied
+// client-side. Zero-valued fields do not filter, and a nil request returns all active products.
+type ListProductsRequest struct {
+	// ProductTypes keeps only products of one of the given types, ignoring case, e.g. types.PRODUCT_MCR.
+	ProductTypes []string
+	// ProvisioningStatuses keeps only products in one of the given provisioning states.
+	ProvisioningStatuses []string
+	// IncludeInactive keeps decommissioned and cancelled products, which are left out by default.
+	IncludeInactive bool
+	// NameContains keeps products whose name contains the given text, ignoring case.
+	NameContains string
+	NameRegex    *regexp.Regexp
+}
+
+// listProductsOptions are the query parameters of the products endpoint.
+type listProductsOptions struct {
+	IncludeInactive bool `url:"includeInactive,omitempty"`
+}
+
 func NewProductServiceOp(c *Client) *ProductServiceOp {
 	return &ProductServiceOp{
 		Client: c,
@@ -96,6 +123,133 @@ func (svc *ProductServiceOp) ExecuteOrder(ctx context.Context, requestBody inter
 	return &body, nil
 }
 
+// ValidateOrder checks an order against the network design validate endpoint and returns its price without buying
+// it. An order the API rejects as invalid is reported in the quote's Errors rather than as an error.
+func (svc *ProductServiceOp) ValidateOrder(ctx context.Context, requestBody interface{}) (*types.OrderQuote, error) {
+	path := "/v3/networkdesign/validate"
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	req, err := svc.Client.NewRequest(ctx, http.MethodPost, url, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	validateResponse := types.OrderValidateResponse{}
+	_, err = svc.Client.Do(ctx, req, &validateResponse)
+
+	var errorResponse *ErrorResponse
+	if errors.Is(err, ErrValidation) && errors.As(err, &errorResponse) {
+		reason := errorResponse.Message
+		if errorResponse.Data != "" {
+			reason += ": " + errorResponse.Data
+		}
+		return &types.OrderQuote{
+			Valid:  false,
+			Errors: []string{reason},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &types.OrderQuote{Valid: true}
+	for _, item := range validateResponse.Data {
+		quote.Items = appendQuoteItems(quote.Items, item)
+	}
+	return quote, nil
+}
+
+// appendQuoteItems flattens an order item and the VXCs and IXs nested under it into quote items. Items without a
+// price, such as the port a VXC is ordered on, are left out.
+func appendQuoteItems(items []types.OrderQuoteItem, item types.OrderValidateItem) []types.OrderQuoteItem {
+	if item.Price != (types.OrderPrice{}) {
+		items = append(items, types.OrderQuoteItem{
+			ProductName: item.ProductName,
+			ProductType: item.ProductType,
+			Price:       item.Price,
+		})
+	}
+	for _, vxc := range item.AssociatedVXCs {
+		items = appendQuoteItems(items, vxc)
+	}
+	for _, ix := range item.AssociatedIXs {
+		items = appendQuoteItems(items, ix)
+	}
+	return items
+}
+
+// ListProducts lists the company's products that match the filters in req. Each product is decoded by its product
+// type into a *types.Port, *types.MCR, *types.MVE, *types.VXC or *types.IX; products of other types are skipped, as
+// are products that cannot be decoded, which are logged.
+func (svc *ProductServiceOp) ListProducts(ctx context.Context, req *ListProductsRequest) ([]types.Product, error) {
+	if req == nil {
+		req = &ListProductsRequest{}
+	}
+
+	path := "/v2/products"
+	url, err := addOptions(svc.Client.BaseURL.JoinPath(path).String(), &listProductsOptions{
+		IncludeInactive: req.IncludeInactive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	productsResponse := types.ProductsResponse{}
+	_, err = svc.Client.Do(ctx, clientReq, &productsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter on each product's summary first, so that only the products that match are decoded in full.
+	products := make([]types.Product, 0, len(productsResponse.Data))
+	for _, item := range productsResponse.Data {
+		summary := types.ProductSummary{}
+		if err := json.Unmarshal(item, &summary); err != nil {
+			svc.Client.Logger.Warn("skipping product that could not be decoded", "error", err.Error())
+			continue
+		}
+		if !req.matches(&summary) {
+			continue
+		}
+
+		product, err := types.UnmarshalProductAs(summary.Type, item)
+		if err != nil {
+			svc.Client.Logger.Warn("skipping product that could not be decoded", "error", err.Error(), "product_uid", summary.UID)
+			continue
+		}
+		if product != nil {
+			products = append(products, product)
+		}
+	}
+	return products, nil
+}
+
+func (req *ListProductsRequest) matches(product types.Product) bool {
+	if !req.IncludeInactive && !isActiveProduct(product) {
+		return false
+	}
+	if len(req.ProductTypes) > 0 && !slices.ContainsFunc(req.ProductTypes, func(productType string) bool {
+		return strings.EqualFold(productType, product.GetType())
+	}) {
+		return false
+	}
+	if len(req.ProvisioningStatuses) > 0 && !slices.Contains(req.ProvisioningStatuses, product.GetProvisioningStatus()) {
+		return false
+	}
+	if req.NameContains != "" && !strings.Contains(strings.ToLower(product.GetName()), strings.ToLower(req.NameContains)) {
+		return false
+	}
+	if req.NameRegex != nil && !req.NameRegex.MatchString(product.GetName()) {
+		return false
+	}
+	return true
+}
+
 // ModifyProduct modifies a product. The available fields to modify are Name, Cost Centre, and Marketplace Visibility.
 func (svc *ProductServiceOp) ModifyProduct(ctx context.Context, req *ModifyProductRequest) (*ModifyProductResponse, error) {
 
@@ -124,7 +278,7 @@ func (svc *ProductServiceOp) ModifyProduct(ctx context.Context, req *ModifyProdu
 			return &ModifyProductResponse{IsUpdated: true}, nil
 		}
 	} else {
-		return nil, errors.New(mega_err.ERR_WRONG_PRODUCT_MODIFY)
+		return nil, newClientError(ErrValidation, mega_err.ERR_WRONG_PRODUCT_MODIFY)
 	}
 }
 
@@ -205,3 +359,12 @@ func (svc *ProductServiceOp) ManageProductLock(ctx context.Context, req *ManageP
 		return &ManageProductLockResponse{}, nil
 	}
 }
+
+// isActiveProduct reports whether a product is not being or has not been decommissioned, and has not been cancelled.
+func isActiveProduct(product types.Product) bool {
+	switch product.GetProvisioningStatus() {
+	case types.STATUS_DECOMMISSIONING, types.STATUS_DECOMMISSIONED, types.STATUS_CANCELLED:
+		return false
+	}
+	return true
+}
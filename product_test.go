@@ -0,0 +1,291 @@
+package megaport
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testProductsJSON = `{"message":"ok","terms":"","data":[
+	{"productUid":"port-1","productName":"Test Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000,"locationId":3,"attributeTags":{"env":"prod"}},
+	{"productUid":"mcr-1","productName":"Test MCR","productType":"MCR2","provisioningStatus":"LIVE","portSpeed":5000,"resources":{"virtual_router":{"mcrAsn":133937}}},
+	{"productUid":"mve-1","productName":"Test MVE","productType":"MVE","provisioningStatus":"CONFIGURED","vendor":"Fortinet","mveSize":"MEDIUM"},
+	{"productUid":"vxc-1","productName":"Test VXC","productType":"VXC","provisioningStatus":"LIVE","rateLimit":100},
+	{"productUid":"ix-1","productName":"Test IX","productType":"IX","provisioningStatus":"LIVE","rateLimit":1000,"vlan":2,"asn":65000,"networkServiceType":"Sydney IX"},
+	{"productUid":"unknown-1","productName":"Something New","productType":"FUTURE_PRODUCT"}
+]}`
+
+func handleTestProducts(t *testing.T) {
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testProductsJSON)
+	})
+}
+
+func TestListProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleTestProducts(t)
+
+	products, err := client.ProductService.ListProducts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, products, 5)
+
+	port, ok := products[0].(*types.Port)
+	assert.True(t, ok)
+	assert.Equal(t, 10000, port.PortSpeed)
+	assert.Equal(t, "prod", port.AttributeTags["env"])
+
+	mcr, ok := products[1].(*types.MCR)
+	assert.True(t, ok)
+	assert.Equal(t, "Test MCR", mcr.GetName())
+
+	mve, ok := products[2].(*types.MVE)
+	assert.True(t, ok)
+	assert.Equal(t, "Fortinet", mve.Vendor)
+
+	vxc, ok := products[3].(*types.VXC)
+	assert.True(t, ok)
+	assert.Equal(t, 100, vxc.RateLimit)
+
+	ix, ok := products[4].(*types.IX)
+	assert.True(t, ok)
+	assert.Equal(t, 65000, ix.ASN)
+	assert.Equal(t, "Sydney IX", ix.NetworkServiceType)
+
+	for _, product := range products {
+		assert.NotEmpty(t, product.GetUID())
+		assert.NotEmpty(t, product.GetType())
+		assert.NotEmpty(t, product.GetProvisioningStatus())
+	}
+}
+
+func TestListProducts_skipsUndecodableProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var logged bytes.Buffer
+	client.Logger = slog.New(slog.NewTextHandler(&logged, nil))
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"port-1","productName":"Test Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000},
+			{"productUid":"port-bad","productName":"Bad Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":"fast"},
+			{"productUid":"mcr-1","productName":"Test MCR","productType":"MCR2","provisioningStatus":"LIVE","portSpeed":5000}
+		]}`)
+	})
+
+	products, err := client.ProductService.ListProducts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "port-1", products[0].GetUID())
+	assert.Equal(t, "mcr-1", products[1].GetUID())
+	assert.Contains(t, logged.String(), "skipping product that could not be decoded")
+
+	ports, err := client.PortService.ListPorts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, ports, 1)
+}
+
+func TestListProducts_decodesOnlyMatchingProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var logged bytes.Buffer
+	client.Logger = slog.New(slog.NewTextHandler(&logged, nil))
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"port-bad","productName":"Bad Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":"fast"},
+			{"productUid":"mcr-1","productName":"Test MCR","productType":"MCR2","provisioningStatus":"LIVE","portSpeed":5000}
+		]}`)
+	})
+
+	mcrs, err := client.MCRService.ListMCRs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, mcrs, 1)
+	assert.Empty(t, logged.String())
+}
+
+func TestListProducts_excludesDecommissioning(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"port-1","productName":"Test Port","productType":"MEGAPORT","provisioningStatus":"LIVE"},
+			{"productUid":"port-2","productName":"Old Port","productType":"MEGAPORT","provisioningStatus":"DECOMMISSIONING"}
+		]}`)
+	})
+
+	products, err := client.ProductService.ListProducts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Equal(t, "port-1", products[0].GetUID())
+
+	products, err = client.ProductService.ListProducts(ctx, &ListProductsRequest{IncludeInactive: true})
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+}
+
+func TestListProductsByType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleTestProducts(t)
+
+	ports, err := client.PortService.ListPorts(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, ports, 1)
+	assert.Equal(t, "port-1", ports[0].UID)
+
+	mcrs, err := client.MCRService.ListMCRs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, mcrs, 1)
+	assert.Equal(t, "mcr-1", mcrs[0].UID)
+
+	mves, err := client.MVEService.ListMVEs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, mves, 1)
+	assert.Equal(t, "mve-1", mves[0].UID)
+}
+
+func TestListProducts_filters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testListPortsJSON)
+	})
+
+	tests := []struct {
+		name string
+		req  *ListProductsRequest
+		want []string
+	}{
+		{"default excludes inactive", nil, []string{"port-1", "port-2", "port-3", "mcr-1"}},
+		{"include inactive", &ListProductsRequest{IncludeInactive: true}, []string{"port-1", "port-2", "port-3", "port-4", "port-5", "mcr-1"}},
+		{"product type", &ListProductsRequest{ProductTypes: []string{types.PRODUCT_MCR}}, []string{"mcr-1"}},
+		{"status", &ListProductsRequest{ProvisioningStatuses: []string{shared.SERVICE_CONFIGURED}}, []string{"port-3"}},
+		{"name contains", &ListProductsRequest{NameContains: "sydney"}, []string{"port-1", "port-2", "mcr-1"}},
+		{"name regex", &ListProductsRequest{NameRegex: regexp.MustCompile(`MCR$`)}, []string{"mcr-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := client.ProductService.ListProducts(ctx, tt.req)
+			assert.NoError(t, err)
+
+			uids := []string{}
+			for _, product := range products {
+				uids = append(uids, product.GetUID())
+			}
+			assert.Equal(t, tt.want, uids)
+		})
+	}
+
+	mcrs, err := client.MCRService.ListMCRs(ctx, &ListProductsRequest{NameContains: "sydney"})
+	assert.NoError(t, err)
+	assert.Len(t, mcrs, 1)
+}
+
+func TestListProducts_includeInactiveQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		query = r.URL.Query()
+		fmt.Fprint(w, testListPortsJSON)
+	})
+
+	_, err := client.ProductService.ListProducts(ctx, nil)
+	assert.NoError(t, err)
+	assert.False(t, query.Has("includeInactive"))
+
+	_, err = client.PortService.ListPorts(ctx, &ListPortsRequest{IncludeInactive: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "true", query.Get("includeInactive"))
+}
+
+func TestValidateOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/validate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"Validation passed","terms":"","data":[
+			{"productName":"Test Port","productType":"MEGAPORT","price":{"currency":"AUD","monthlyRate":300,"monthlySetup":0,"hourlyRate":0.41}},
+			{"productUid":"port-1","associatedVxcs":[{"productName":"Test VXC","price":{"currency":"AUD","monthlyRate":75.5,"mbpsRate":0.5}}]}
+		]}`)
+	})
+
+	quote, err := client.ProductService.ValidateOrder(ctx, []interface{}{})
+	assert.NoError(t, err)
+	assert.True(t, quote.Valid)
+	assert.Empty(t, quote.Errors)
+	assert.Len(t, quote.Items, 2)
+	assert.Equal(t, "Test Port", quote.Items[0].ProductName)
+	assert.Equal(t, 300.0, quote.Items[0].Price.MonthlyRate)
+	assert.Equal(t, "Test VXC", quote.Items[1].ProductName)
+	assert.Equal(t, 0.5, quote.Items[1].Price.MbpsRate)
+
+	total, currency := quote.MonthlyTotal()
+	assert.Equal(t, 375.5, total)
+	assert.Equal(t, "AUD", currency)
+}
+
+func TestValidateOrder_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/validate", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"Validation failed","terms":"","data":"portSpeed 3000 is not available at this location"}`)
+	})
+
+	quote, err := client.ProductService.ValidateOrder(ctx, []interface{}{})
+	assert.NoError(t, err)
+	assert.False(t, quote.Valid)
+	assert.Empty(t, quote.Items)
+	assert.Equal(t, []string{"Validation failed: portSpeed 3000 is not available at this location"}, quote.Errors)
+}
+
+func TestBuy_dryRun(t *testing.T) {
+	setup()
+	defer teardown()
+	handleTestLocations()
+
+	mux.HandleFunc("/v3/networkdesign/validate", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"Validation passed","terms":"","data":[{"productName":"Test","price":{"currency":"USD","monthlyRate":100}}]}`)
+	})
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a dry run must not buy")
+	})
+
+	port, err := client.PortService.BuySinglePort(ctx, &BuySinglePortRequest{Name: "Test", Term: 1, PortSpeed: 1000, LocationId: 3, DryRun: true})
+	assert.NoError(t, err)
+	assert.Empty(t, port.TechnicalServiceUID)
+	assert.True(t, port.Quote.Valid)
+
+	mcr, err := client.MCRService.BuyMCR(ctx, &BuyMCRRequest{Name: "Test", Term: 1, PortSpeed: 1000, LocationID: 3, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, mcr.Quote.Items[0].Price.MonthlyRate)
+
+	vxc, err := client.VXCService.BuyVXC(ctx, &BuyVXCRequest{PortUID: "port-1", VXCName: "Test", RateLimit: 100, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", vxc.Quote.Items[0].Price.Currency)
+
+	_, err = client.PortService.ValidatePortOrder(ctx, &BuyPortRequest{Name: "Test", Term: 7})
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -0,0 +1,293 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// TopologyNode is a product in a Topology. Nodes for products outside the account, such as the partner port at
+// the B-End of a cloud VXC, are marked External and have no Product.
+type TopologyNode struct {
+	UID                string        `json:"uid"`
+	Name               string        `json:"name"`
+	Type               string        `json:"type,omitempty"`
+	ProvisioningStatus string        `json:"provisioningStatus,omitempty"`
+	LocationID         int           `json:"locationId,omitempty"`
+	Speed              int           `json:"speed,omitempty"`
+	External           bool          `json:"external,omitempty"`
+	Product            types.Product `json:"-"`
+}
+
+// TopologyEndpoint is one end of a TopologyEdge.
+type TopologyEndpoint struct {
+	UID       string `json:"uid"`
+	VLAN      int    `json:"vlan,omitempty"`
+	InnerVLAN int    `json:"innerVlan,omitempty"`
+}
+
+// TopologyEdge is a VXC between two products, or an IX attached to a port. For an IX the B-End is the IX itself.
+type TopologyEdge struct {
+	UID                string           `json:"uid"`
+	Name               string           `json:"name"`
+	Type               string           `json:"type"`
+	ProvisioningStatus string           `json:"provisioningStatus,omitempty"`
+	RateLimit          int              `json:"rateLimit,omitempty"`
+	AEnd               TopologyEndpoint `json:"aEnd"`
+	BEnd               TopologyEndpoint `json:"bEnd"`
+	Connection         types.Product    `json:"-"`
+}
+
+// Topology is a graph of an account's products and the VXCs and IXs connecting them.
+type Topology struct {
+	Nodes map[string]*TopologyNode
+	Edges map[string]*TopologyEdge
+
+	adjacency map[string][]*TopologyEdge
+}
+
+// NewTopology builds a Topology from products, such as those returned by ProductService.ListProducts. VXCs and IXs
+// are taken both from the products themselves and from the connections associated with ports, MCRs and MVEs.
+func NewTopology(products []types.Product) *Topology {
+	t := &Topology{
+		Nodes:     map[string]*TopologyNode{},
+		Edges:     map[string]*TopologyEdge{},
+		adjacency: map[string][]*TopologyEdge{},
+	}
+
+	var vxcs []*types.VXC
+	var ixs []*types.IX
+	ixParents := map[string]string{}
+
+	for _, product := range products {
+		switch p := product.(type) {
+		case *types.Port:
+			t.addNode(&TopologyNode{UID: p.UID, Name: p.Name, Type: types.PRODUCT_MEGAPORT, ProvisioningStatus: p.ProvisioningStatus, LocationID: p.LocationID, Speed: p.PortSpeed, Product: p})
+			vxcs = append(vxcs, p.AssociatedVXCs...)
+			ixs = appendIXs(ixs, ixParents, p.UID, p.AssociatedIXs)
+		case *types.MCR:
+			t.addNode(&TopologyNode{UID: p.UID, Name: p.Name, Type: types.PRODUCT_MCR, ProvisioningStatus: p.ProvisioningStatus, LocationID: p.LocationID, Speed: p.PortSpeed, Product: p})
+			vxcs = append(vxcs, p.AssociatedVXCs...)
+			ixs = appendIXs(ixs, ixParents, p.UID, p.AssociatedIXs)
+		case *types.MVE:
+			t.addNode(&TopologyNode{UID: p.UID, Name: p.Name, Type: types.PRODUCT_MVE, ProvisioningStatus: p.ProvisioningStatus, LocationID: p.LocationID, Product: p})
+			vxcs = append(vxcs, p.AssociatedVXCs...)
+			ixs = appendIXs(ixs, ixParents, p.UID, p.AssociatedIXs)
+		case *types.VXC:
+			vxcs = append(vxcs, p)
+		case *types.IX:
+			ixs = append(ixs, p)
+		}
+	}
+
+	for _, vxc := range vxcs {
+		t.addVXC(vxc)
+	}
+	for _, ix := range ixs {
+		t.addIX(ix, ixParents[ix.UID])
+	}
+
+	return t
+}
+
+// BuildTopology lists the account's active products and builds a Topology from them. Decommissioned and cancelled
+// products are left out; pass them to NewTopology to include them.
+func (svc *ProductServiceOp) BuildTopology(ctx context.Context) (*Topology, error) {
+	products, err := svc.ListProducts(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewTopology(products), nil
+}
+
+func appendIXs(ixs []*types.IX, parents map[string]string, parentUID string, associated []*types.IX) []*types.IX {
+	for _, ix := range associated {
+		parents[ix.UID] = parentUID
+	}
+	return append(ixs, associated...)
+}
+
+func (t *Topology) addNode(node *TopologyNode) {
+	if existing, ok := t.Nodes[node.UID]; ok && !existing.External {
+		return
+	}
+	t.Nodes[node.UID] = node
+}
+
+// addEndpointNode adds an external node for a VXC end that is not one of the account's products.
+func (t *Topology) addEndpointNode(end types.VXCEndConfiguration) {
+	if _, ok := t.Nodes[end.UID]; ok || end.UID == "" {
+		return
+	}
+	t.Nodes[end.UID] = &TopologyNode{
+		UID:        end.UID,
+		Name:       end.Name,
+		LocationID: end.LocationID,
+		External:   true,
+	}
+}
+
+func (t *Topology) addEdge(edge *TopologyEdge) {
+	if _, ok := t.Edges[edge.UID]; ok {
+		return
+	}
+	t.Edges[edge.UID] = edge
+	t.adjacency[edge.AEnd.UID] = append(t.adjacency[edge.AEnd.UID], edge)
+	if edge.BEnd.UID != edge.AEnd.UID {
+		t.adjacency[edge.BEnd.UID] = append(t.adjacency[edge.BEnd.UID], edge)
+	}
+}
+
+func (t *Topology) addVXC(vxc *types.VXC) {
+	if vxc == nil || vxc.UID == "" {
+		return
+	}
+	t.addEndpointNode(vxc.AEndConfiguration)
+	t.addEndpointNode(vxc.BEndConfiguration)
+	t.addEdge(&TopologyEdge{
+		UID:                vxc.UID,
+		Name:               vxc.Name,
+		Type:               types.PRODUCT_VXC,
+		ProvisioningStatus: vxc.ProvisioningStatus,
+		RateLimit:          vxc.RateLimit,
+		AEnd:               TopologyEndpoint{UID: vxc.AEndConfiguration.UID, VLAN: vxc.AEndConfiguration.VLAN, InnerVLAN: vxc.AEndConfiguration.InnerVLAN},
+		BEnd:               TopologyEndpoint{UID: vxc.BEndConfiguration.UID, VLAN: vxc.BEndConfiguration.VLAN, InnerVLAN: vxc.BEndConfiguration.InnerVLAN},
+		Connection:         vxc,
+	})
+}
+
+// addIX adds an IX as a node attached to the product it was ordered on. IXs whose parent is unknown are added
+// without an edge.
+func (t *Topology) addIX(ix *types.IX, parentUID string) {
+	if ix == nil || ix.UID == "" {
+		return
+	}
+	t.addNode(&TopologyNode{UID: ix.UID, Name: ix.NetworkServiceType, Type: types.PRODUCT_IX, ProvisioningStatus: ix.ProvisioningStatus, LocationID: ix.LocationID, Speed: ix.RateLimit, Product: ix})
+	if parentUID == "" {
+		return
+	}
+	t.addEdge(&TopologyEdge{
+		UID:                ix.UID,
+		Name:               ix.Name,
+		Type:               types.PRODUCT_IX,
+		ProvisioningStatus: ix.ProvisioningStatus,
+		RateLimit:          ix.RateLimit,
+		AEnd:               TopologyEndpoint{UID: parentUID, VLAN: ix.VLAN},
+		BEnd:               TopologyEndpoint{UID: ix.UID},
+		Connection:         ix,
+	})
+}
+
+// Connections returns the VXCs and IXs attached to the product with the given UID.
+func (t *Topology) Connections(uid string) []*TopologyEdge {
+	return t.adjacency[uid]
+}
+
+// Neighbours returns the products directly connected to the product with the given UID.
+func (t *Topology) Neighbours(uid string) []*TopologyNode {
+	neighbours := []*TopologyNode{}
+	seen := map[string]bool{uid: true}
+	for _, edge := range t.adjacency[uid] {
+		other := edge.BEnd.UID
+		if other == uid {
+			other = edge.AEnd.UID
+		}
+		if seen[other] {
+			continue
+		}
+		seen[other] = true
+		if node, ok := t.Nodes[other]; ok {
+			neighbours = append(neighbours, node)
+		}
+	}
+	return neighbours
+}
+
+// Traverse visits every product reachable from the product with the given UID in breadth-first order, starting
+// with the product itself. Traversal stops early if visit returns false.
+func (t *Topology) Traverse(uid string, visit func(node *TopologyNode, depth int) bool) {
+	start, ok := t.Nodes[uid]
+	if !ok {
+		return
+	}
+
+	type entry struct {
+		node  *TopologyNode
+		depth int
+	}
+	queue := []entry{{start, 0}}
+	seen := map[string]bool{uid: true}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !visit(current.node, current.depth) {
+			return
+		}
+		for _, neighbour := range t.Neighbours(current.node.UID) {
+			if !seen[neighbour.UID] {
+				seen[neighbour.UID] = true
+				queue = append(queue, entry{neighbour, current.depth + 1})
+			}
+		}
+	}
+}
+
+// Orphans returns the account's active ports, MCRs and MVEs that have no VXCs or IXs attached, sorted by name.
+// Decommissioned and cancelled products are never orphans, as nothing can be attached to them.
+func (t *Topology) Orphans() []*TopologyNode {
+	orphans := []*TopologyNode{}
+	for _, node := range t.NodeList() {
+		if node.External || node.Type == types.PRODUCT_IX || !isActiveProduct(node.Product) {
+			continue
+		}
+		if len(t.adjacency[node.UID]) == 0 {
+			orphans = append(orphans, node)
+		}
+	}
+	return orphans
+}
+
+// NodeList returns the topology's nodes sorted by name, then UID.
+func (t *Topology) NodeList() []*TopologyNode {
+	nodes := make([]*TopologyNode, 0, len(t.Nodes))
+	for _, node := range t.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if c := strings.Compare(nodes[i].Name, nodes[j].Name); c != 0 {
+			return c < 0
+		}
+		return nodes[i].UID < nodes[j].UID
+	})
+	return nodes
+}
+
+// EdgeList returns the topology's edges sorted by name, then UID.
+func (t *Topology) EdgeList() []*TopologyEdge {
+	edges := make([]*TopologyEdge, 0, len(t.Edges))
+	for _, edge := range t.Edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if c := strings.Compare(edges[i].Name, edges[j].Name); c != 0 {
+			return c < 0
+		}
+		return edges[i].UID < edges[j].UID
+	})
+	return edges
+}
+
+// MarshalJSON exports the topology as sorted lists of nodes and edges.
+func (t *Topology) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Nodes []*TopologyNode `json:"nodes"`
+		Edges []*TopologyEdge `json:"edges"`
+	}{
+		Nodes: t.NodeList(),
+		Edges: t.EdgeList(),
+	})
+}
@@ -0,0 +1,235 @@
+package megaport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/megaport/megaportgo/types"
+)
+
+// TopologyGrouping controls how products are grouped when a Topology is exported.
+type TopologyGrouping int
+
+const (
+	// GroupByLocation groups products by the data centre they are in.
+	GroupByLocation TopologyGrouping = iota
+	// GroupByMetro groups products by the metro area of their data centre.
+	GroupByMetro
+)
+
+// TopologyExportOptions configures WriteDOT and WriteMermaid.
+type TopologyExportOptions struct {
+	// Locations name the location and metro groups, e.g. from LocationService.ListLocations. Products in
+	// locations that are not listed are grouped under their location ID.
+	Locations []types.Location
+	GroupBy   TopologyGrouping
+}
+
+// topologyGroup is a set of nodes rendered as one DOT cluster or Mermaid subgraph.
+type topologyGroup struct {
+	label string
+	nodes []*TopologyNode
+}
+
+// WriteDOT renders the topology as an undirected Graphviz DOT graph with one cluster per location or metro.
+func (t *Topology) WriteDOT(w io.Writer, opts *TopologyExportOptions) error {
+	groups, ungrouped := t.exportGroups(opts)
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "graph megaport {")
+	fmt.Fprintln(b, "\trankdir=LR;")
+	fmt.Fprintln(b, "\tnode [shape=box];")
+
+	writeNode := func(indent string, node *TopologyNode) {
+		style := ""
+		if node.External {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(b, "%s%s [label=%s%s];\n", indent, dotQuote(node.UID), dotQuote(strings.Join(nodeLabelLines(node), "\n")), style)
+	}
+
+	for i, group := range groups {
+		fmt.Fprintf(b, "\tsubgraph cluster_%d {\n", i)
+		fmt.Fprintf(b, "\t\tlabel=%s;\n", dotQuote(group.label))
+		for _, node := range group.nodes {
+			writeNode("\t\t", node)
+		}
+		fmt.Fprintln(b, "\t}")
+	}
+	for _, node := range ungrouped {
+		writeNode("\t", node)
+	}
+
+	for _, edge := range t.EdgeList() {
+		// An edge with an unknown end, such as a VXC whose B-End UID is empty, cannot be drawn.
+		if t.Nodes[edge.AEnd.UID] == nil || t.Nodes[edge.BEnd.UID] == nil {
+			continue
+		}
+		fmt.Fprintf(b, "\t%s -- %s [label=%s];\n", dotQuote(edge.AEnd.UID), dotQuote(edge.BEnd.UID), dotQuote(strings.Join(edgeLabelLines(edge), "\n")))
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// WriteMermaid renders the topology as a Mermaid flowchart with one subgraph per location or metro.
+func (t *Topology) WriteMermaid(w io.Writer, opts *TopologyExportOptions) error {
+	groups, ungrouped := t.exportGroups(opts)
+
+	// Mermaid node IDs cannot contain arbitrary characters, so nodes are numbered in NodeList order.
+	ids := map[string]string{}
+	for i, node := range t.NodeList() {
+		ids[node.UID] = fmt.Sprintf("n%d", i)
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "flowchart LR")
+
+	writeNode := func(indent string, node *TopologyNode) {
+		open, close := "[", "]"
+		if node.External {
+			open, close = "([", "])"
+		}
+		fmt.Fprintf(b, "%s%s%s%s%s\n", indent, ids[node.UID], open, mermaidQuote(strings.Join(nodeLabelLines(node), "<br/>")), close)
+	}
+
+	for i, group := range groups {
+		fmt.Fprintf(b, "    subgraph g%d[%s]\n", i, mermaidQuote(group.label))
+		for _, node := range group.nodes {
+			writeNode("        ", node)
+		}
+		fmt.Fprintln(b, "    end")
+	}
+	for _, node := range ungrouped {
+		writeNode("    ", node)
+	}
+
+	for _, edge := range t.EdgeList() {
+		aEnd, bEnd := ids[edge.AEnd.UID], ids[edge.BEnd.UID]
+		if aEnd == "" || bEnd == "" {
+			continue
+		}
+		fmt.Fprintf(b, "    %s ---|%s| %s\n", aEnd, mermaidQuote(strings.Join(edgeLabelLines(edge), "<br/>")), bEnd)
+	}
+
+	return b.Flush()
+}
+
+// exportGroups groups the topology's nodes by location or metro. Nodes without a location are returned separately.
+func (t *Topology) exportGroups(opts *TopologyExportOptions) ([]topologyGroup, []*TopologyNode) {
+	if opts == nil {
+		opts = &TopologyExportOptions{}
+	}
+
+	locations := map[int]types.Location{}
+	for _, location := range opts.Locations {
+		locations[location.ID] = location
+	}
+
+	byLabel := map[string]*topologyGroup{}
+	ungrouped := []*TopologyNode{}
+	for _, node := range t.NodeList() {
+		if node.LocationID == 0 {
+			ungrouped = append(ungrouped, node)
+			continue
+		}
+
+		label := fmt.Sprintf("Location %d", node.LocationID)
+		if location, ok := locations[node.LocationID]; ok {
+			label = location.Name
+			if opts.GroupBy == GroupByMetro && location.Metro != "" {
+				label = location.Metro
+			}
+		}
+
+		group, ok := byLabel[label]
+		if !ok {
+			group = &topologyGroup{label: label}
+			byLabel[label] = group
+		}
+		group.nodes = append(group.nodes, node)
+	}
+
+	groups := make([]topologyGroup, 0, len(byLabel))
+	for _, group := range byLabel {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].label < groups[j].label })
+
+	return groups, ungrouped
+}
+
+func nodeLabelLines(node *TopologyNode) []string {
+	kind := "External"
+	switch node.Type {
+	case types.PRODUCT_MEGAPORT:
+		kind = "Port"
+	case types.PRODUCT_MCR:
+		kind = "MCR"
+	case types.PRODUCT_MVE:
+		kind = "MVE"
+	case types.PRODUCT_IX:
+		kind = "IX"
+	}
+	if node.Speed > 0 {
+		kind += " " + formatSpeed(node.Speed)
+	}
+
+	lines := []string{node.Name, kind}
+	if node.ProvisioningStatus != "" {
+		lines = append(lines, node.ProvisioningStatus)
+	}
+	return lines
+}
+
+func edgeLabelLines(edge *TopologyEdge) []string {
+	lines := []string{edge.Name}
+	if edge.RateLimit > 0 {
+		lines = append(lines, formatSpeed(edge.RateLimit))
+	}
+
+	switch {
+	case edge.Type == types.PRODUCT_IX:
+		lines = append(lines, fmt.Sprintf("VLAN %d", edge.AEnd.VLAN))
+	case edge.AEnd.VLAN != 0 || edge.BEnd.VLAN != 0:
+		lines = append(lines, fmt.Sprintf("VLAN %s/%s", formatVLAN(edge.AEnd), formatVLAN(edge.BEnd)))
+	}
+
+	if edge.ProvisioningStatus != "" {
+		lines = append(lines, edge.ProvisioningStatus)
+	}
+	return lines
+}
+
+// formatSpeed formats a speed in Mbps, using Gbps for whole gigabits.
+func formatSpeed(mbps int) string {
+	if mbps >= 1000 && mbps%1000 == 0 {
+		return fmt.Sprintf("%d Gbps", mbps/1000)
+	}
+	return fmt.Sprintf("%d Mbps", mbps)
+}
+
+func formatVLAN(end TopologyEndpoint) string {
+	if end.VLAN == 0 {
+		return "untagged"
+	}
+	if end.InnerVLAN != 0 {
+		return fmt.Sprintf("%d.%d", end.VLAN, end.InnerVLAN)
+	}
+	return fmt.Sprint(end.VLAN)
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "|", "#124;")
+
+func mermaidQuote(s string) string {
+	return `"` + mermaidEscaper.Replace(s) + `"`
+}
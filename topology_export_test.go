@@ -0,0 +1,107 @@
+package megaport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTopologyLocations = []types.Location{
+	{ID: 3, Name: "Equinix SY1", Metro: "Sydney"},
+	{ID: 4, Name: "Global Switch Sydney", Metro: "Sydney"},
+}
+
+const testTopologyDOT = `graph megaport {
+	rankdir=LR;
+	node [shape=box];
+	subgraph cluster_0 {
+		label="Equinix SY1";
+		"ix-1" [label="Sydney IX\nIX 1 Gbps\nLIVE"];
+		"mcr-1" [label="Sydney MCR\nMCR 5 Gbps\nLIVE"];
+		"port-1" [label="Sydney Port\nPort 10 Gbps\nLIVE"];
+	}
+	subgraph cluster_1 {
+		label="Location 4";
+		"aws-1" [label="Asia Pacific (Sydney) (ap-southeast-2)\nExternal", style=dashed];
+		"mve-1" [label="Idle MVE\nMVE\nLIVE"];
+		"port-2" [label="Idle Port\nPort 1 Gbps\nLIVE"];
+	}
+	"port-1" -- "aws-1" [label="Port to AWS\n1 Gbps\nVLAN 101/untagged\nCONFIGURED"];
+	"port-1" -- "mcr-1" [label="Port to MCR\n500 Mbps\nVLAN 100/200\nLIVE"];
+	"port-1" -- "ix-1" [label="Sydney IX Service\n1 Gbps\nVLAN 300\nLIVE"];
+}
+`
+
+const testTopologyMermaid = `flowchart LR
+    subgraph g0["Sydney"]
+        n0(["Asia Pacific (Sydney) (ap-southeast-2)<br/>External"])
+        n1["Idle MVE<br/>MVE<br/>LIVE"]
+        n2["Idle Port<br/>Port 1 Gbps<br/>LIVE"]
+        n3["Sydney IX<br/>IX 1 Gbps<br/>LIVE"]
+        n4["Sydney MCR<br/>MCR 5 Gbps<br/>LIVE"]
+        n5["Sydney Port<br/>Port 10 Gbps<br/>LIVE"]
+    end
+    n5 ---|"Port to AWS<br/>1 Gbps<br/>VLAN 101/untagged<br/>CONFIGURED"| n0
+    n5 ---|"Port to MCR<br/>500 Mbps<br/>VLAN 100/200<br/>LIVE"| n4
+    n5 ---|"Sydney IX Service<br/>1 Gbps<br/>VLAN 300<br/>LIVE"| n3
+`
+
+func TestTopologyWriteDOT(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	// Location 4 is left out so that its products are grouped by ID.
+	out := &strings.Builder{}
+	assert.NoError(t, topology.WriteDOT(out, &TopologyExportOptions{Locations: testTopologyLocations[:1]}))
+	assert.Equal(t, testTopologyDOT, out.String())
+}
+
+func TestTopologyWriteMermaid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	out := &strings.Builder{}
+	assert.NoError(t, topology.WriteMermaid(out, &TopologyExportOptions{Locations: testTopologyLocations, GroupBy: GroupByMetro}))
+	assert.Equal(t, testTopologyMermaid, out.String())
+}
+
+func TestTopologyExportQuoting(t *testing.T) {
+	topology := NewTopology([]types.Product{
+		&types.Port{UID: "port-1", Name: `Port "A" | B`, Type: "MEGAPORT", ProvisioningStatus: "LIVE"},
+	})
+
+	dot := &strings.Builder{}
+	assert.NoError(t, topology.WriteDOT(dot, nil))
+	assert.Contains(t, dot.String(), `"port-1" [label="Port \"A\" | B\nPort\nLIVE"];`)
+
+	mermaid := &strings.Builder{}
+	assert.NoError(t, topology.WriteMermaid(mermaid, nil))
+	assert.Contains(t, mermaid.String(), `n0["Port #quot;A#quot; #124; B<br/>Port<br/>LIVE"]`)
+}
+
+func TestTopologyExportSkipsDanglingEdges(t *testing.T) {
+	topology := NewTopology([]types.Product{
+		&types.Port{UID: "port-1", Name: "Sydney Port", Type: "MEGAPORT", ProvisioningStatus: "LIVE"},
+		&types.VXC{
+			UID:                "vxc-1",
+			Name:               "Dangling VXC",
+			ProvisioningStatus: "LIVE",
+			AEndConfiguration:  types.VXCEndConfiguration{UID: "port-1"},
+		},
+	})
+
+	dot := &strings.Builder{}
+	assert.NoError(t, topology.WriteDOT(dot, nil))
+	assert.NotContains(t, dot.String(), "--")
+	assert.NotContains(t, dot.String(), `""`)
+
+	mermaid := &strings.Builder{}
+	assert.NoError(t, topology.WriteMermaid(mermaid, nil))
+	assert.NotContains(t, mermaid.String(), "---")
+}
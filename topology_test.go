@@ -0,0 +1,168 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTopologyJSON = `{"message":"ok","terms":"","data":[
+	{"productUid":"port-1","productName":"Sydney Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":10000,"locationId":3,
+		"associatedVxcs":[
+			{"productUid":"vxc-1","productName":"Port to MCR","productType":"VXC","provisioningStatus":"LIVE","rateLimit":500,
+				"aEnd":{"productUid":"port-1","productName":"Sydney Port","locationId":3,"vlan":100},
+				"bEnd":{"productUid":"mcr-1","productName":"Sydney MCR","locationId":3,"vlan":200}},
+			{"productUid":"vxc-2","productName":"Port to AWS","productType":"VXC","provisioningStatus":"CONFIGURED","rateLimit":1000,
+				"aEnd":{"productUid":"port-1","productName":"Sydney Port","locationId":3,"vlan":101},
+				"bEnd":{"productUid":"aws-1","productName":"Asia Pacific (Sydney) (ap-southeast-2)","locationId":4,"vlan":0}}
+		],
+		"associatedIxs":[
+			{"productUid":"ix-1","productName":"Sydney IX Service","productType":"IX","provisioningStatus":"LIVE","rateLimit":1000,"vlan":300,"networkServiceType":"Sydney IX","locationId":3}
+		]},
+	{"productUid":"mcr-1","productName":"Sydney MCR","productType":"MCR2","provisioningStatus":"LIVE","portSpeed":5000,"locationId":3,
+		"associatedVxcs":[
+			{"productUid":"vxc-1","productName":"Port to MCR","productType":"VXC","provisioningStatus":"LIVE","rateLimit":500,
+				"aEnd":{"productUid":"port-1","productName":"Sydney Port","locationId":3,"vlan":100},
+				"bEnd":{"productUid":"mcr-1","productName":"Sydney MCR","locationId":3,"vlan":200}}
+		]},
+	{"productUid":"mve-1","productName":"Idle MVE","productType":"MVE","provisioningStatus":"LIVE","locationId":4},
+	{"productUid":"port-2","productName":"Idle Port","productType":"MEGAPORT","provisioningStatus":"LIVE","portSpeed":1000,"locationId":4}
+]}`
+
+func buildTestTopology(t *testing.T) *Topology {
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testTopologyJSON)
+	})
+
+	topology, err := client.ProductService.BuildTopology(ctx)
+	assert.NoError(t, err)
+	return topology
+}
+
+func TestBuildTopology(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	assert.Len(t, topology.Nodes, 6)
+	assert.Len(t, topology.Edges, 3)
+
+	aws := topology.Nodes["aws-1"]
+	assert.True(t, aws.External)
+	assert.Equal(t, 4, aws.LocationID)
+
+	port := topology.Nodes["port-1"]
+	assert.False(t, port.External)
+	assert.IsType(t, &types.Port{}, port.Product)
+	assert.Equal(t, 10000, port.Speed)
+
+	vxc := topology.Edges["vxc-1"]
+	assert.Equal(t, types.PRODUCT_VXC, vxc.Type)
+	assert.Equal(t, TopologyEndpoint{UID: "port-1", VLAN: 100}, vxc.AEnd)
+	assert.Equal(t, TopologyEndpoint{UID: "mcr-1", VLAN: 200}, vxc.BEnd)
+
+	ix := topology.Edges["ix-1"]
+	assert.Equal(t, types.PRODUCT_IX, ix.Type)
+	assert.Equal(t, "port-1", ix.AEnd.UID)
+	assert.Equal(t, 300, ix.AEnd.VLAN)
+	assert.Equal(t, "Sydney IX", topology.Nodes["ix-1"].Name)
+
+	assert.Len(t, topology.Connections("port-1"), 3)
+	assert.Len(t, topology.Connections("mcr-1"), 1)
+
+	neighbours := []string{}
+	for _, node := range topology.Neighbours("port-1") {
+		neighbours = append(neighbours, node.UID)
+	}
+	assert.ElementsMatch(t, []string{"mcr-1", "aws-1", "ix-1"}, neighbours)
+}
+
+func TestTopologyTraverse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	depths := map[string]int{}
+	topology.Traverse("mcr-1", func(node *TopologyNode, depth int) bool {
+		depths[node.UID] = depth
+		return true
+	})
+	assert.Equal(t, map[string]int{"mcr-1": 0, "port-1": 1, "aws-1": 2, "ix-1": 2}, depths)
+
+	visited := 0
+	topology.Traverse("mcr-1", func(node *TopologyNode, depth int) bool {
+		visited++
+		return depth == 0
+	})
+	assert.Equal(t, 2, visited)
+}
+
+func TestTopologyOrphans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	orphans := topology.Orphans()
+	assert.Len(t, orphans, 2)
+	assert.Equal(t, "mve-1", orphans[0].UID)
+	assert.Equal(t, "port-2", orphans[1].UID)
+}
+
+func TestBuildTopology_activeOnly(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/products", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("includeInactive"))
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"productUid":"port-1","productName":"Sydney Port","productType":"MEGAPORT","provisioningStatus":"LIVE"},
+			{"productUid":"port-2","productName":"Old Port","productType":"MEGAPORT","provisioningStatus":"DECOMMISSIONED"},
+			{"productUid":"mcr-1","productName":"Cancelled MCR","productType":"MCR2","provisioningStatus":"CANCELLED"}
+		]}`)
+	})
+
+	topology, err := client.ProductService.BuildTopology(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, topology.Nodes, 1)
+	assert.Contains(t, topology.Nodes, "port-1")
+}
+
+func TestTopologyOrphans_skipsInactive(t *testing.T) {
+	topology := NewTopology([]types.Product{
+		&types.Port{UID: "port-1", Name: "Sydney Port", ProvisioningStatus: "LIVE"},
+		&types.Port{UID: "port-2", Name: "Old Port", ProvisioningStatus: types.STATUS_DECOMMISSIONED},
+		&types.MCR{UID: "mcr-1", Name: "Cancelled MCR", ProvisioningStatus: types.STATUS_CANCELLED},
+		&types.MVE{UID: "mve-1", Name: "Leaving MVE", ProvisioningStatus: types.STATUS_DECOMMISSIONING},
+	})
+
+	orphans := topology.Orphans()
+	assert.Len(t, orphans, 1)
+	assert.Equal(t, "port-1", orphans[0].UID)
+}
+
+func TestTopologyMarshalJSON(t *testing.T) {
+	setup()
+	defer teardown()
+
+	topology := buildTestTopology(t)
+
+	exported, err := json.Marshal(topology)
+	assert.NoError(t, err)
+
+	decoded := struct {
+		Nodes []TopologyNode `json:"nodes"`
+		Edges []TopologyEdge `json:"edges"`
+	}{}
+	assert.NoError(t, json.Unmarshal(exported, &decoded))
+	assert.Len(t, decoded.Nodes, 6)
+	assert.Len(t, decoded.Edges, 3)
+	assert.Equal(t, "Asia Pacific (Sydney) (ap-southeast-2)", decoded.Nodes[0].Name)
+	assert.Equal(t, "Port to AWS", decoded.Edges[0].Name)
+}
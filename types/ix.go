@@ -0,0 +1,110 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+type IXOrder struct {
+	PortID        string                 `json:"productUid"`
+	AssociatedIXs []IXOrderConfiguration `json:"associatedIxs"`
+}
+
+type IXOrderConfiguration struct {
+	Name               string `json:"productName"`
+	NetworkServiceType string `json:"networkServiceType"`
+	ASN                int    `json:"asn"`
+	MACAddress         string `json:"macAddress"`
+	RateLimit          int    `json:"rateLimit"`
+	VLAN               int    `json:"vlan"`
+}
+
+type IXOrderConfirmation struct {
+	TechnicalServiceUID string `json:"technicalServiceUid"`
+	// Quote is set instead of TechnicalServiceUID when the order was a dry run.
+	Quote *OrderQuote `json:"-"`
+}
+
+// IXNetwork is an Internet Exchange that can be ordered at a location. Its Name is used as the NetworkServiceType
+// of an IX order.
+type IXNetwork struct {
+	Name        string `json:"name"`
+	ASN         int    `json:"asn"`
+	FullName    string `json:"fullName"`
+	Description string `json:"description"`
+	GroupMetro  string `json:"groupMetro"`
+}
+
+type IXUpdate struct {
+	Name       string `json:"name,omitempty"`
+	RateLimit  int    `json:"rateLimit,omitempty"`
+	VLAN       int    `json:"vlan,omitempty"`
+	MACAddress string `json:"macAddress,omitempty"`
+	ASN        int    `json:"asn,omitempty"`
+}
+
+type IX struct {
+	ID                 int                    `json:"productId"`
+	UID                string                 `json:"productUid"`
+	Name               string                 `json:"productName"`
+	Type               string                 `json:"productType"`
+	ProvisioningStatus string                 `json:"provisioningStatus"`
+	CreateDate         int                    `json:"createDate"`
+	CreatedBy          string                 `json:"createdBy"`
+	TerminateDate      int                    `json:"terminateDate"`
+	LiveDate           int                    `json:"liveDate"`
+	Market             string                 `json:"market"`
+	LocationID         int                    `json:"locationId"`
+	UsageAlgorithm     string                 `json:"usageAlgorithm"`
+	SecondaryName      string                 `json:"secondaryName"`
+	RateLimit          int                    `json:"rateLimit"`
+	VLAN               int                    `json:"vlan"`
+	MACAddress         string                 `json:"macAddress"`
+	ASN                int                    `json:"asn"`
+	NetworkServiceType string                 `json:"networkServiceType"`
+	CompanyUID         string                 `json:"companyUid"`
+	CompanyName        string                 `json:"companyName"`
+	ContractStartDate  int                    `json:"contractStartDate"`
+	ContractEndDate    int                    `json:"contractEndDate"`
+	ContractTermMonths int                    `json:"contractTermMonths"`
+	AttributeTags      map[string]interface{} `json:"attributeTags"`
+	Locked             bool                   `json:"locked"`
+	AdminLocked        bool                   `json:"adminLocked"`
+	Cancelable         bool                   `json:"cancelable"`
+	Resources          IXResources            `json:"resources"`
+}
+
+type IXResources struct {
+	Interface      PortInterface     `json:"interface"`
+	BGPConnections []IXBGPConnection `json:"bgp_connection"`
+	IPAddresses    []IXIPAddress     `json:"ip_address"`
+}
+
+type IXBGPConnection struct {
+	ASN               int    `json:"asn"`
+	CustomerASN       int    `json:"customer_asn"`
+	CustomerIPAddress string `json:"customer_ip_address"`
+	ISPASN            int    `json:"isp_asn"`
+	ISPIPAddress      string `json:"isp_ip_address"`
+	IXPeerPolicy      string `json:"ix_peer_policy"`
+	MaxPrefixes       int    `json:"max_prefixes"`
+	ResourceName      string `json:"resource_name"`
+	ResourceType      string `json:"resource_type"`
+}
+
+type IXIPAddress struct {
+	Address      string `json:"address"`
+	ResourceName string `json:"resource_name"`
+	ResourceType string `json:"resource_type"`
+	Version      int    `json:"version"`
+	ReverseDNS   string `json:"reverse_dns"`
+}
@@ -15,21 +15,97 @@
 package types
 
 type Location struct {
-	Name             string                 `json:"name"`
-	Country          string                 `json:"country"`
-	LiveDate         int                    `json:"liveDate"`
-	SiteCode         string                 `json:"siteCode"`
-	NetworkRegion    string                 `json:"networkRegion"`
-	Address          map[string]string      `json:"address"`
-	Campus           string                 `json:"campus"`
-	Latitude         float64                `json:"latitude"`
-	Longitude        float64                `json:"longitude"`
-	Products         map[string]interface{} `json:"products"`
-	Market           string                 `json:"market"`
-	Metro            string                 `json:"metro"`
-	VRouterAvailable bool                   `json:"vRouterAvailable"`
-	ID               int                    `json:"id"`
-	Status           string                 `json:"status"`
+	Name             string            `json:"name"`
+	Country          string            `json:"country"`
+	LiveDate         int               `json:"liveDate"`
+	SiteCode         string            `json:"siteCode"`
+	NetworkRegion    string            `json:"networkRegion"`
+	Address          map[string]string `json:"address"`
+	Campus           string            `json:"campus"`
+	Latitude         float64           `json:"latitude"`
+	Longitude        float64           `json:"longitude"`
+	Products         LocationProducts  `json:"products"`
+	Market           string            `json:"market"`
+	Metro            string            `json:"metro"`
+	VRouterAvailable bool              `json:"vRouterAvailable"`
+	ID               int               `json:"id"`
+	Status           string            `json:"status"`
+}
+
+// LocationProducts describes the products a location can deliver.
+type LocationProducts struct {
+	// MCR reports whether MCRs can be ordered at the location.
+	MCR        bool `json:"mcr"`
+	MCRVersion int  `json:"mcrVersion"`
+	// Megaport lists the port speeds available at the location in Gbps.
+	Megaport []int `json:"megaport"`
+	// MCR1 and MCR2 list the MCR speeds available at the location in Mbps.
+	MCR1         []int                `json:"mcr1"`
+	MCR2         []int                `json:"mcr2"`
+	MVE          []LocationMVE        `json:"mve"`
+	CrossConnect LocationCrossConnect `json:"crossConnect"`
+}
+
+// LocationMVE is an MVE image that can be deployed at a location and the sizes it is available in.
+type LocationMVE struct {
+	ID                int                  `json:"id"`
+	Product           string               `json:"product"`
+	Vendor            string               `json:"vendor"`
+	VendorDescription string               `json:"vendorDescription"`
+	Version           string               `json:"version"`
+	ReleaseImage      bool                 `json:"releaseImage"`
+	MaxCPUCount       int                  `json:"maxCpuCount"`
+	Sizes             []MVEInstanceSize    `json:"sizes"`
+	Details           []LocationMVEDetails `json:"details"`
+}
+
+type LocationMVEDetails struct {
+	Size          MVEInstanceSize `json:"size"`
+	Label         string          `json:"label"`
+	CPUCoreCount  int             `json:"cpuCoreCount"`
+	RamGB         int             `json:"ramGB"`
+	BandwidthMbps int             `json:"bandwidthMbps"`
+}
+
+type LocationCrossConnect struct {
+	Available bool   `json:"available"`
+	Type      string `json:"type"`
+}
+
+// SupportsPortSpeed reports whether ports of the given speed in Mbps can be ordered at the location.
+func (p LocationProducts) SupportsPortSpeed(speed int) bool {
+	for _, gbps := range p.Megaport {
+		if gbps*1000 == speed {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsMCRSpeed reports whether MCRs of the given speed in Mbps can be ordered at the location.
+func (p LocationProducts) SupportsMCRSpeed(speed int) bool {
+	for _, mbps := range p.MCR2 {
+		if mbps == speed {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsMVESize reports whether an MVE of the given size can be deployed at the location. An empty size matches
+// any MVE.
+func (p LocationProducts) SupportsMVESize(size MVEInstanceSize) bool {
+	for _, mve := range p.MVE {
+		if size == "" && len(mve.Sizes) > 0 {
+			return true
+		}
+		for _, available := range mve.Sizes {
+			if available == size {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 type Country struct {
@@ -38,3 +114,18 @@ type Country struct {
 	Prefix    string `json:"prefix"`
 	SiteCount int    `json:"siteCount"`
 }
+
+// NetworkRegion is a Megaport network region and the countries it has sites in.
+type NetworkRegion struct {
+	Countries     []Country `json:"countries"`
+	NetworkRegion string    `json:"networkRegion"`
+}
+
+// SiteCount returns the number of sites in the region.
+func (r NetworkRegion) SiteCount() int {
+	total := 0
+	for _, country := range r.Countries {
+		total += country.SiteCount
+	}
+	return total
+}
@@ -29,6 +29,8 @@ type MCROrderConfig struct {
 
 type MCROrderConfirmation struct {
 	TechnicalServiceUID string `json:"technicalServiceUid"`
+	// Quote is set instead of TechnicalServiceUID when the order was a dry run.
+	Quote *OrderQuote `json:"-"`
 }
 
 type MCR struct {
@@ -64,6 +66,8 @@ type MCR struct {
 	AdminLocked           bool              `json:"adminLocked"`
 	Cancelable            bool              `json:"cancelable"`
 	Resources             MCRResources      `json:"resources"`
+	AssociatedVXCs        []*VXC            `json:"associatedVxcs"`
+	AssociatedIXs         []*IX             `json:"associatedIxs"`
 }
 
 type MCRResources struct {
@@ -81,6 +85,7 @@ type MCRVirtualRouter struct {
 }
 
 type MCRPrefixFilterList struct {
+	ID            int                  `json:"id,omitempty"`
 	Description   string               `json:"description"`
 	AddressFamily string               `json:"addressFamily"`
 	Entries       []MCRPrefixListEntry `json:"entries"`
@@ -92,3 +97,8 @@ type MCRPrefixListEntry struct {
 	Ge     int    `json:"ge,omitempty"`
 	Le     int    `json:"le,omitempty"`
 }
+
+const PREFIX_LIST_IPV4 string = "IPv4"
+const PREFIX_LIST_IPV6 string = "IPv6"
+const PREFIX_LIST_ACTION_PERMIT string = "permit"
+const PREFIX_LIST_ACTION_DENY string = "deny"
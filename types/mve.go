@@ -14,13 +14,15 @@
 
 package types
 
+import "encoding/json"
+
 type MVEOrderConfig struct {
 	LocationID        int                    `json:"locationId"`
 	Name              string                 `json:"productName"`
 	Term              int                    `json:"term"`
 	ProductType       string                 `json:"productType"`
 	NetworkInterfaces []*MVENetworkInterface `json:"vnics"`
-	VendorConfig      map[string]interface{} `json:"vendorConfig"`
+	VendorConfig      VendorConfig           `json:"vendorConfig"`
 }
 
 // NetworkInterface represents a vNIC.
@@ -41,6 +43,8 @@ const (
 
 type MVEOrderConfirmation struct {
 	TechnicalServiceUID string `json:"technicalServiceUid"`
+	// Quote is set instead of TechnicalServiceUID when the order was a dry run.
+	Quote *OrderQuote `json:"-"`
 }
 
 type MVE struct {
@@ -71,8 +75,189 @@ type MVE struct {
 	Locked                bool                   `json:"locked"`
 	AdminLocked           bool                   `json:"adminLocked"`
 	Cancelable            bool                   `json:"cancelable"`
-	Resources             map[string]interface{} `json:"resources"`
+	Resources             MVEResources           `json:"resources"`
 	Vendor                string                 `json:"vendor"`
 	Size                  string                 `json:"mveSize"`
 	NetworkInterfaces     []*MVENetworkInterface `json:"vnics"`
+	AssociatedVXCs        []*VXC                 `json:"associatedVxcs"`
+	AssociatedIXs         []*IX                  `json:"associatedIxs"`
+}
+
+type MVEResources struct {
+	Interface       PortInterface       `json:"interface"`
+	VirtualMachines []MVEVirtualMachine `json:"virtual_machine"`
+}
+
+type MVEVirtualMachine struct {
+	ID                int                    `json:"id"`
+	CPUCount          int                    `json:"cpu_count"`
+	Image             MVEVirtualMachineImage `json:"image"`
+	ResourceType      string                 `json:"resource_type"`
+	Up                bool                   `json:"up"`
+	NetworkInterfaces []MVENetworkInterface  `json:"vnics"`
+}
+
+type MVEVirtualMachineImage struct {
+	ID      int    `json:"id"`
+	Vendor  string `json:"vendor"`
+	Product string `json:"product"`
+	Version string `json:"version"`
+}
+
+type MVEUpdate struct {
+	Name       string `json:"name"`
+	CostCentre string `json:"costCentre,omitempty"`
+}
+
+const MVE_VENDOR_ARUBA string = "aruba"
+const MVE_VENDOR_CISCO string = "cisco"
+const MVE_VENDOR_FORTINET string = "fortinet"
+const MVE_VENDOR_PALO_ALTO string = "palo_alto"
+const MVE_VENDOR_VERSA string = "versa"
+const MVE_VENDOR_VMWARE string = "vmware"
+const MVE_VENDOR_6WIND string = "6wind"
+
+// VendorConfig is the vendor-specific part of an MVE order. Each implementation marshals with its "vendor"
+// discriminator set, so callers never need to supply it themselves.
+type VendorConfig interface {
+	VendorName() string
+}
+
+type ArubaConfig struct {
+	ImageID     int             `json:"imageId"`
+	ProductSize MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel    string          `json:"mveLabel,omitempty"`
+	AccountName string          `json:"accountName"`
+	AccountKey  string          `json:"accountKey"`
+	SystemTag   string          `json:"systemTag,omitempty"`
+}
+
+func (c ArubaConfig) VendorName() string { return MVE_VENDOR_ARUBA }
+
+func (c ArubaConfig) MarshalJSON() ([]byte, error) {
+	type config ArubaConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type CiscoConfig struct {
+	ImageID            int             `json:"imageId"`
+	ProductSize        MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel           string          `json:"mveLabel,omitempty"`
+	AdminSSHPublicKey  string          `json:"adminSshPublicKey"`
+	SSHPublicKey       string          `json:"sshPublicKey,omitempty"`
+	CloudInit          string          `json:"cloudInit,omitempty"`
+	ManageLocally      bool            `json:"manageLocally"`
+	FMCIPAddress       string          `json:"fmcIpAddress,omitempty"`
+	FMCRegistrationKey string          `json:"fmcRegistrationKey,omitempty"`
+	FMCNatID           string          `json:"fmcNatId,omitempty"`
+}
+
+func (c CiscoConfig) VendorName() string { return MVE_VENDOR_CISCO }
+
+func (c CiscoConfig) MarshalJSON() ([]byte, error) {
+	type config CiscoConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type FortinetConfig struct {
+	ImageID           int             `json:"imageId"`
+	ProductSize       MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel          string          `json:"mveLabel,omitempty"`
+	AdminSSHPublicKey string          `json:"adminSshPublicKey"`
+	SSHPublicKey      string          `json:"sshPublicKey,omitempty"`
+	LicenseData       string          `json:"licenseData,omitempty"`
+}
+
+func (c FortinetConfig) VendorName() string { return MVE_VENDOR_FORTINET }
+
+func (c FortinetConfig) MarshalJSON() ([]byte, error) {
+	type config FortinetConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type PaloAltoConfig struct {
+	ImageID           int             `json:"imageId"`
+	ProductSize       MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel          string          `json:"mveLabel,omitempty"`
+	AdminSSHPublicKey string          `json:"adminSshPublicKey,omitempty"`
+	SSHPublicKey      string          `json:"sshPublicKey,omitempty"`
+	AdminPasswordHash string          `json:"adminPasswordHash,omitempty"`
+	LicenseData       string          `json:"licenseData,omitempty"`
+}
+
+func (c PaloAltoConfig) VendorName() string { return MVE_VENDOR_PALO_ALTO }
+
+func (c PaloAltoConfig) MarshalJSON() ([]byte, error) {
+	type config PaloAltoConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type VersaConfig struct {
+	ImageID           int             `json:"imageId"`
+	ProductSize       MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel          string          `json:"mveLabel,omitempty"`
+	DirectorAddress   string          `json:"directorAddress"`
+	ControllerAddress string          `json:"controllerAddress"`
+	LocalAuth         string          `json:"localAuth"`
+	RemoteAuth        string          `json:"remoteAuth"`
+	SerialNumber      string          `json:"serialNumber"`
+}
+
+func (c VersaConfig) VendorName() string { return MVE_VENDOR_VERSA }
+
+func (c VersaConfig) MarshalJSON() ([]byte, error) {
+	type config VersaConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type VmwareConfig struct {
+	ImageID           int             `json:"imageId"`
+	ProductSize       MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel          string          `json:"mveLabel,omitempty"`
+	AdminSSHPublicKey string          `json:"adminSshPublicKey"`
+	SSHPublicKey      string          `json:"sshPublicKey,omitempty"`
+	VcoAddress        string          `json:"vcoAddress"`
+	VcoActivationCode string          `json:"vcoActivationCode"`
+}
+
+func (c VmwareConfig) VendorName() string { return MVE_VENDOR_VMWARE }
+
+func (c VmwareConfig) MarshalJSON() ([]byte, error) {
+	type config VmwareConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
+}
+
+type SixwindVSRConfig struct {
+	ImageID      int             `json:"imageId"`
+	ProductSize  MVEInstanceSize `json:"productSize,omitempty"`
+	MVELabel     string          `json:"mveLabel,omitempty"`
+	SSHPublicKey string          `json:"sshPublicKey"`
+}
+
+func (c SixwindVSRConfig) VendorName() string { return MVE_VENDOR_6WIND }
+
+func (c SixwindVSRConfig) MarshalJSON() ([]byte, error) {
+	type config SixwindVSRConfig
+	return json.Marshal(struct {
+		Vendor string `json:"vendor"`
+		config
+	}{c.VendorName(), config(c)})
 }
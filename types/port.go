@@ -24,11 +24,14 @@ type PortOrder struct {
 	Virtual               bool   `json:"virtual"`
 	Market                string `json:"market"`
 	LagPortCount          int    `json:"lagPortCount,omitempty"`
+	LagID                 int    `json:"lagId,omitempty"`
 	MarketplaceVisibility bool   `json:"marketplaceVisibility"`
 }
 
 type PortOrderConfirmation struct {
 	TechnicalServiceUID string `json:"technicalServiceUid"`
+	// Quote is set instead of TechnicalServiceUID when the order was a dry run.
+	Quote *OrderQuote `json:"-"`
 }
 
 type Port struct {
@@ -64,6 +67,8 @@ type Port struct {
 	AdminLocked           bool                   `json:"adminLocked"`
 	Cancelable            bool                   `json:"cancelable"`
 	VXCResources          PortResources          `json:"resources"`
+	AssociatedVXCs        []*VXC                 `json:"associatedVxcs"`
+	AssociatedIXs         []*IX                  `json:"associatedIxs"`
 }
 
 type PortResources struct {
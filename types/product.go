@@ -14,8 +14,106 @@
 
 package types
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type ProductUpdate struct {
 	Name                 string `json:"name"`
 	CostCentre           string `json:"costCentre"`
 	MarketplaceVisbility bool   `json:"marketplaceVisibility"`
 }
+
+// Product is implemented by every product type returned by the products endpoint.
+type Product interface {
+	GetUID() string
+	GetName() string
+	GetType() string
+	GetProvisioningStatus() string
+}
+
+var (
+	_ Product = &Port{}
+	_ Product = &MCR{}
+	_ Product = &MVE{}
+	_ Product = &VXC{}
+	_ Product = &IX{}
+)
+
+func (p *Port) GetUID() string                { return p.UID }
+func (p *Port) GetName() string               { return p.Name }
+func (p *Port) GetType() string               { return p.Type }
+func (p *Port) GetProvisioningStatus() string { return p.ProvisioningStatus }
+
+func (m *MCR) GetUID() string                { return m.UID }
+func (m *MCR) GetName() string               { return m.Name }
+func (m *MCR) GetType() string               { return m.Type }
+func (m *MCR) GetProvisioningStatus() string { return m.ProvisioningStatus }
+
+func (m *MVE) GetUID() string                { return m.UID }
+func (m *MVE) GetName() string               { return m.Name }
+func (m *MVE) GetType() string               { return m.Type }
+func (m *MVE) GetProvisioningStatus() string { return m.ProvisioningStatus }
+
+func (v *VXC) GetUID() string                { return v.UID }
+func (v *VXC) GetName() string               { return v.Name }
+func (v *VXC) GetType() string               { return v.Type }
+func (v *VXC) GetProvisioningStatus() string { return v.ProvisioningStatus }
+
+func (i *IX) GetUID() string                { return i.UID }
+func (i *IX) GetName() string               { return i.Name }
+func (i *IX) GetType() string               { return i.Type }
+func (i *IX) GetProvisioningStatus() string { return i.ProvisioningStatus }
+
+// ProductSummary holds the fields every product shares. Decoding a product into it is enough to filter products
+// without decoding them in full.
+type ProductSummary struct {
+	UID                string `json:"productUid"`
+	Name               string `json:"productName"`
+	Type               string `json:"productType"`
+	ProvisioningStatus string `json:"provisioningStatus"`
+}
+
+var _ Product = &ProductSummary{}
+
+func (s *ProductSummary) GetUID() string                { return s.UID }
+func (s *ProductSummary) GetName() string               { return s.Name }
+func (s *ProductSummary) GetType() string               { return s.Type }
+func (s *ProductSummary) GetProvisioningStatus() string { return s.ProvisioningStatus }
+
+// UnmarshalProduct decodes a single product into the concrete type for its productType. It returns a nil Product
+// and no error for product types this library does not model.
+func UnmarshalProduct(data []byte) (Product, error) {
+	summary := ProductSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	return UnmarshalProductAs(summary.Type, data)
+}
+
+// UnmarshalProductAs decodes a single product whose productType is already known, such as from a ProductSummary,
+// into the concrete type for productType. It returns a nil Product and no error for product types this library does
+// not model.
+func UnmarshalProductAs(productType string, data []byte) (Product, error) {
+	var product Product
+	switch strings.ToLower(productType) {
+	case PRODUCT_MEGAPORT:
+		product = &Port{}
+	case PRODUCT_MCR:
+		product = &MCR{}
+	case PRODUCT_MVE:
+		product = &MVE{}
+	case PRODUCT_VXC:
+		product = &VXC{}
+	case PRODUCT_IX:
+		product = &IX{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
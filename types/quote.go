@@ -0,0 +1,70 @@
+// Copyright 2020 Megaport Pty Ltd
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//       https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// OrderPrice is the price the API quotes for one item of an order.
+type OrderPrice struct {
+	Currency             string  `json:"currency"`
+	HourlySetup          float64 `json:"hourlySetup"`
+	DailySetup           float64 `json:"dailySetup"`
+	MonthlySetup         float64 `json:"monthlySetup"`
+	HourlyRate           float64 `json:"hourlyRate"`
+	DailyRate            float64 `json:"dailyRate"`
+	MonthlyRate          float64 `json:"monthlyRate"`
+	MonthlyRackRate      float64 `json:"monthlyRackRate"`
+	FixedRecurringCharge float64 `json:"fixedRecurringCharge"`
+	MbpsRate             float64 `json:"mbpsRate"`
+}
+
+// OrderValidateItem is one item of an order as returned by the network design validate endpoint. VXCs and IXs
+// ordered on a port are nested under it.
+type OrderValidateItem struct {
+	ProductName    string              `json:"productName"`
+	ProductType    string              `json:"productType"`
+	Price          OrderPrice          `json:"price"`
+	AssociatedVXCs []OrderValidateItem `json:"associatedVxcs"`
+	AssociatedIXs  []OrderValidateItem `json:"associatedIxs"`
+}
+
+// OrderQuoteItem is the price of one product in an order.
+type OrderQuoteItem struct {
+	ProductName string
+	ProductType string
+	Price       OrderPrice
+}
+
+// OrderQuote is the result of validating an order without buying it. If the order is not valid, Errors holds the
+// reasons given by the API and Items is empty.
+type OrderQuote struct {
+	Valid  bool
+	Items  []OrderQuoteItem
+	Errors []string
+}
+
+// MonthlyTotal returns the combined monthly rate of the quoted items and their currency. The currency is empty if
+// the items are quoted in more than one currency.
+func (q *OrderQuote) MonthlyTotal() (float64, string) {
+	total := 0.0
+	currency := ""
+	for i, item := range q.Items {
+		total += item.Price.MonthlyRate
+		if i == 0 {
+			currency = item.Price.Currency
+		} else if item.Price.Currency != currency {
+			currency = ""
+		}
+	}
+	return total, currency
+}
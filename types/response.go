@@ -14,6 +14,8 @@
 
 package types
 
+import "encoding/json"
+
 type GenericResponse struct {
 	Message string                 `json:"message"`
 	Terms   string                 `json:"terms"`
@@ -64,6 +66,12 @@ type PortResponse struct {
 	Data    Port   `json:"data"`
 }
 
+type ProductsResponse struct {
+	Message string            `json:"message"`
+	Terms   string            `json:"terms"`
+	Data    []json.RawMessage `json:"data"`
+}
+
 type VXCOrderResponse struct {
 	Message string                 `json:"message"`
 	Terms   string                 `json:"terms"`
@@ -106,6 +114,24 @@ type MVEResponse struct {
 	Data    MVE    `json:"data"`
 }
 
+type IXOrderResponse struct {
+	Message string                `json:"message"`
+	Terms   string                `json:"terms"`
+	Data    []IXOrderConfirmation `json:"data"`
+}
+
+type IXResponse struct {
+	Message string `json:"message"`
+	Terms   string `json:"terms"`
+	Data    IX     `json:"data"`
+}
+
+type IXNetworksResponse struct {
+	Message string       `json:"message"`
+	Terms   string       `json:"terms"`
+	Data    []*IXNetwork `json:"data"`
+}
+
 type PrefixFilterList struct {
 	Id            int    `json:"id"`
 	Description   string `json:"description"`
@@ -118,6 +144,12 @@ type MCRPrefixFilterListResponse struct {
 	Data    []PrefixFilterList `json:"data"`
 }
 
+type MCRPrefixFilterListDetailResponse struct {
+	Message string              `json:"message"`
+	Terms   string              `json:"terms"`
+	Data    MCRPrefixFilterList `json:"data"`
+}
+
 type PartnerLookupResponse struct {
 	Message string        `json:"message"`
 	Data    PartnerLookup `json:"data"`
@@ -125,12 +157,18 @@ type PartnerLookupResponse struct {
 }
 
 type CountryResponse struct {
-	Message string                 `json:"message"`
-	Terms   string                 `json:"terms"`
-	Data    []CountryInnerResponse `json:"data"`
+	Message string          `json:"message"`
+	Terms   string          `json:"terms"`
+	Data    []NetworkRegion `json:"data"`
 }
 
-type CountryInnerResponse struct {
-	Countries     []Country `json:"countries"`
-	NetworkRegion string    `json:"networkRegion"`
+// CountryInnerResponse is the previous name of NetworkRegion.
+//
+// Deprecated: use NetworkRegion.
+type CountryInnerResponse = NetworkRegion
+
+type OrderValidateResponse struct {
+	Message string              `json:"message"`
+	Terms   string              `json:"terms"`
+	Data    []OrderValidateItem `json:"data"`
 }
@@ -32,15 +32,26 @@ const APPLICATION_SHORT_NAME = "mpt"
 const PRODUCT_MEGAPORT = "megaport"
 const PRODUCT_VXC = "vxc"
 const PRODUCT_MCR = "mcr2"
+const PRODUCT_MCR_ORDER = "MCR2" // The product type MCRs are ordered with.
 const PRODUCT_MVE = "mve"
 const PRODUCT_IX = "ix"
 
+const STATUS_DECOMMISSIONING string = "DECOMMISSIONING"
 const STATUS_DECOMMISSIONED string = "DECOMMISSIONED"
 const STATUS_CANCELLED string = "CANCELLED"
 const SINGLE_PORT string = "Single"
 const LAG_PORT string = "LAG"
 const CONNECT_TYPE_AWS_VIF string = "AWS"
 const CONNECT_TYPE_AWS_HOSTED_CONNECTION string = "AWSHC"
+const CONNECT_TYPE_AZURE string = "AZURE"
+const CONNECT_TYPE_GOOGLE string = "GOOGLE"
+const CONNECT_TYPE_ORACLE string = "ORACLE"
+const AWS_VIF_TYPE_PRIVATE string = "private"
+const AWS_VIF_TYPE_PUBLIC string = "public"
+const AZURE_PEERING_TYPE_PRIVATE string = "private"
+const AZURE_PEERING_TYPE_MICROSOFT string = "microsoft"
+const PARTNER_PORT_PRIMARY string = "primary"
+const PARTNER_PORT_SECONDARY string = "secondary"
 
 const MODIFY_NAME string = "NAME"
 const MODIFY_COST_CENTRE = "COST_CENTRE"
@@ -53,6 +53,8 @@ type VXCOrderBEndConfiguration struct {
 
 type VXCOrderConfirmation struct {
 	TechnicalServiceUID string `json:"vxcJTechnicalServiceUid"`
+	// Quote is set instead of TechnicalServiceUID when the order was a dry run.
+	Quote *OrderQuote `json:"-"`
 }
 
 // BGP CONFIG STUFF
@@ -0,0 +1,200 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+// VXCService is an interface for interfacing with the VXC endpoints
+// of the Megaport API.
+type VXCService interface {
+	BuyVXC(ctx context.Context, req *BuyVXCRequest) (*types.VXCOrderConfirmation, error)
+	ValidateVXCOrder(ctx context.Context, req *BuyVXCRequest) (*types.OrderQuote, error)
+	GetVXC(ctx context.Context, req *GetVXCRequest) (*types.VXC, error)
+	UpdateVXC(ctx context.Context, req *UpdateVXCRequest) (*UpdateVXCResponse, error)
+	DeleteVXC(ctx context.Context, req *DeleteVXCRequest) (*DeleteVXCResponse, error)
+	WaitForVXCProvisioning(ctx context.Context, vxcID string) (bool, error)
+	BuyAWSVXC(ctx context.Context, req *BuyAWSVXCRequest) (*types.VXCOrderConfirmation, error)
+	LookupPartnerPorts(ctx context.Context, req *LookupPartnerPortsRequest) (*types.PartnerLookup, error)
+	BuyAzureVXC(ctx context.Context, req *BuyAzureVXCRequest) (*types.VXCOrderConfirmation, error)
+	BuyGoogleVXC(ctx context.Context, req *BuyGoogleVXCRequest) (*types.VXCOrderConfirmation, error)
+	BuyOracleVXC(ctx context.Context, req *BuyOracleVXCRequest) (*types.VXCOrderConfirmation, error)
+}
+
+// VXCServiceOp handles communication with VXC methods of the Megaport API.
+type VXCServiceOp struct {
+	Client *Client
+}
+
+type BuyVXCRequest struct {
+	PortUID   string
+	VXCName   string
+	RateLimit int
+
+	AEndConfiguration types.VXCOrderAEndConfiguration
+	BEndConfiguration types.VXCOrderBEndConfiguration
+
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+type GetVXCRequest struct {
+	VXCID string
+}
+
+type UpdateVXCRequest struct {
+	VXCID     string
+	Name      string
+	RateLimit int
+	AEndVLAN  int
+	BEndVLAN  *int
+}
+
+type UpdateVXCResponse struct {
+	IsUpdated bool
+}
+
+type DeleteVXCRequest struct {
+	VXCID     string
+	DeleteNow bool
+}
+
+type DeleteVXCResponse struct {
+	IsDeleting bool
+}
+
+func NewVXCServiceOp(c *Client) *VXCServiceOp {
+	return &VXCServiceOp{
+		Client: c,
+	}
+}
+
+// BuyVXC orders a VXC between the A-End product given by PortUID and the B-End product in the B-End configuration.
+func (svc *VXCServiceOp) BuyVXC(ctx context.Context, req *BuyVXCRequest) (*types.VXCOrderConfirmation, error) {
+	return svc.executeVXCOrder(ctx, buildVXCOrder(req), req.DryRun)
+}
+
+// ValidateVXCOrder validates a VXC order and quotes its price without buying it.
+func (svc *VXCServiceOp) ValidateVXCOrder(ctx context.Context, req *BuyVXCRequest) (*types.OrderQuote, error) {
+	return svc.Client.ProductService.ValidateOrder(ctx, buildVXCOrder(req))
+}
+
+func buildVXCOrder(req *BuyVXCRequest) []types.VXCOrder {
+	return []types.VXCOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedVXCs: []types.VXCOrderConfiguration{
+				{
+					Name:      req.VXCName,
+					RateLimit: req.RateLimit,
+					AEnd:      req.AEndConfiguration,
+					BEnd:      req.BEndConfiguration,
+				},
+			},
+		},
+	}
+}
+
+func (svc *VXCServiceOp) GetVXC(ctx context.Context, req *GetVXCRequest) (*types.VXC, error) {
+	path := "/v2/product/" + req.VXCID
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	isError, parsedError := svc.Client.IsErrorResponse(response, &err, 200)
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	vxcDetails := types.VXCResponse{}
+	unmarshalErr := json.Unmarshal(body, &vxcDetails)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return &vxcDetails.Data, nil
+}
+
+// UpdateVXC updates the name, rate limit and VLANs of a VXC. A nil BEndVLAN leaves the B-End VLAN unchanged.
+func (svc *VXCServiceOp) UpdateVXC(ctx context.Context, req *UpdateVXCRequest) (*UpdateVXCResponse, error) {
+	update := types.VXCUpdate{
+		Name:      req.Name,
+		RateLimit: req.RateLimit,
+		AEndVLAN:  req.AEndVLAN,
+		BEndVLAN:  req.BEndVLAN,
+	}
+
+	path := fmt.Sprintf("/v2/product/%s/%s", types.PRODUCT_VXC, req.VXCID)
+	url := svc.Client.BaseURL.JoinPath(path).String()
+
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodPut, url, update)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResponse, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer updateResponse.Body.Close() // nolint
+
+	isResErr, compiledResErr := svc.Client.IsErrorResponse(updateResponse, &err, 200)
+	if isResErr {
+		return nil, compiledResErr
+	}
+
+	return &UpdateVXCResponse{
+		IsUpdated: true,
+	}, nil
+}
+
+func (svc *VXCServiceOp) DeleteVXC(ctx context.Context, req *DeleteVXCRequest) (*DeleteVXCResponse, error) {
+	_, err := svc.Client.ProductService.DeleteProduct(ctx, &DeleteProductRequest{
+		ProductID: req.VXCID,
+		DeleteNow: req.DeleteNow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteVXCResponse{
+		IsDeleting: true,
+	}, nil
+}
+
+// WaitForVXCProvisioning polls the VXC until it reaches one of the target states in the client's WaitOptions
+// (CONFIGURED or LIVE by default). It returns a *ProvisionTimeoutError if the VXC is not ready in time.
+func (svc *VXCServiceOp) WaitForVXCProvisioning(ctx context.Context, vxcID string) (bool, error) {
+	err := svc.Client.waitForProvisioning(ctx, "VXC", vxcID, mega_err.ERR_VXC_PROVISION_TIMEOUT_EXCEED, func(ctx context.Context) (string, error) {
+		details, err := svc.GetVXC(ctx, &GetVXCRequest{
+			VXCID: vxcID,
+		})
+		if err != nil {
+			return "", err
+		}
+		return details.ProvisioningStatus, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,581 @@
+package megaport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/types"
+)
+
+var awsAccountRegex = regexp.MustCompile(`^[0-9]{12}$`)
+var oracleVirtualCircuitRegex = regexp.MustCompile(`^ocid1\.virtualcircuit\.[a-z0-9-]+\.[a-z0-9-]*\.[a-z0-9]+$`)
+
+// PartnerPortSelection narrows down the partner port a cloud VXC is connected to. If PartnerPortUID is set it is
+// used as-is; otherwise the VXC-permitted partner port with the lowest rank that matches every non-empty field is
+// chosen.
+type PartnerPortSelection struct {
+	PartnerPortUID string
+	// ProductName is a case-insensitive substring of the partner port name, such as an AWS region "ap-southeast-2".
+	ProductName   string
+	LocationID    int
+	DiversityZone string
+}
+
+type BuyAWSVXCRequest struct {
+	PortUID   string
+	VXCName   string
+	RateLimit int
+	AEndVLAN  int
+
+	PartnerPort PartnerPortSelection
+
+	// ConnectType is either types.CONNECT_TYPE_AWS_VIF or types.CONNECT_TYPE_AWS_HOSTED_CONNECTION.
+	ConnectType string
+	// Type is the VIF type, types.AWS_VIF_TYPE_PRIVATE or types.AWS_VIF_TYPE_PUBLIC. It is ignored for hosted
+	// connections.
+	Type              string
+	OwnerAccount      string
+	ASN               int
+	AmazonASN         int
+	AuthKey           string
+	Prefixes          string
+	CustomerIPAddress string
+	AmazonIPAddress   string
+	ConnectionName    string
+
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+// BuyAWSVXC validates and orders a VXC to AWS, either as a hosted VIF or a hosted connection.
+func (svc *VXCServiceOp) BuyAWSVXC(ctx context.Context, req *BuyAWSVXCRequest) (*types.VXCOrderConfirmation, error) {
+	if err := validateAWSVXCRequest(req); err != nil {
+		return nil, err
+	}
+
+	partnerPortUID, err := svc.selectPartnerPort(ctx, req.ConnectType, req.PartnerPort)
+	if err != nil {
+		return nil, err
+	}
+
+	partnerConfig := types.AWSVXCOrderBEndPartnerConfig{
+		ConnectType:    req.ConnectType,
+		OwnerAccount:   req.OwnerAccount,
+		ConnectionName: req.ConnectionName,
+	}
+	if req.ConnectType == types.CONNECT_TYPE_AWS_VIF {
+		partnerConfig.Type = req.Type
+		partnerConfig.ASN = req.ASN
+		partnerConfig.AmazonASN = req.AmazonASN
+		partnerConfig.AuthKey = req.AuthKey
+		partnerConfig.Prefixes = req.Prefixes
+		partnerConfig.CustomerIPAddress = req.CustomerIPAddress
+		partnerConfig.AmazonIPAddress = req.AmazonIPAddress
+	}
+
+	buyOrder := []types.AWSVXCOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedVXCs: []types.AWSVXCOrderConfiguration{
+				{
+					Name:      req.VXCName,
+					RateLimit: req.RateLimit,
+					AEnd: types.VXCOrderAEndConfiguration{
+						VLAN: req.AEndVLAN,
+					},
+					BEnd: types.AWSVXCOrderBEndConfiguration{
+						ProductUID:    partnerPortUID,
+						PartnerConfig: partnerConfig,
+					},
+				},
+			},
+		},
+	}
+
+	return svc.executeVXCOrder(ctx, buyOrder, req.DryRun)
+}
+
+func validateAWSVXCRequest(req *BuyAWSVXCRequest) error {
+	if req.ConnectType != types.CONNECT_TYPE_AWS_VIF && req.ConnectType != types.CONNECT_TYPE_AWS_HOSTED_CONNECTION {
+		return newClientError(ErrValidation, mega_err.ERR_INVALID_PARTNER)
+	}
+
+	if !awsAccountRegex.MatchString(req.OwnerAccount) {
+		return NewArgError("ownerAccount", "an AWS account ID must be exactly 12 digits")
+	}
+
+	// Hosted connections are configured on the AWS side once accepted.
+	if req.ConnectType == types.CONNECT_TYPE_AWS_HOSTED_CONNECTION {
+		return nil
+	}
+
+	if req.Type != types.AWS_VIF_TYPE_PRIVATE && req.Type != types.AWS_VIF_TYPE_PUBLIC {
+		return NewArgError("type", fmt.Sprintf("it must be %q or %q", types.AWS_VIF_TYPE_PRIVATE, types.AWS_VIF_TYPE_PUBLIC))
+	}
+
+	if req.ASN <= 0 {
+		return NewArgError("asn", "a customer ASN is required for hosted VIFs")
+	}
+
+	if (req.CustomerIPAddress == "") != (req.AmazonIPAddress == "") {
+		return NewArgError("customerIpAddress", "customer and Amazon IP addresses must be set together")
+	}
+
+	addresses := []struct{ arg, address string }{
+		{"customerIpAddress", req.CustomerIPAddress},
+		{"amazonIpAddress", req.AmazonIPAddress},
+	}
+	for _, a := range addresses {
+		arg, address := a.arg, a.address
+		if address == "" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(address)
+		if err != nil {
+			return NewArgError(arg, fmt.Sprintf("%q is not an address in CIDR notation", address))
+		}
+		if req.Type == types.AWS_VIF_TYPE_PUBLIC && ip.IsPrivate() {
+			return NewArgError(arg, fmt.Sprintf("%q must be a public address for a public VIF", address))
+		}
+	}
+
+	if req.Type == types.AWS_VIF_TYPE_PRIVATE {
+		if req.Prefixes != "" {
+			return NewArgError("prefixes", "prefixes are only advertised on public VIFs")
+		}
+		return nil
+	}
+
+	if req.CustomerIPAddress == "" {
+		return NewArgError("customerIpAddress", "public VIFs require customer and Amazon IP addresses")
+	}
+
+	if req.Prefixes == "" {
+		return NewArgError("prefixes", "public VIFs require at least one prefix to advertise")
+	}
+	for _, prefix := range strings.Split(req.Prefixes, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(prefix)); err != nil {
+			return NewArgError("prefixes", fmt.Sprintf("%q is not a valid CIDR", prefix))
+		}
+	}
+
+	return nil
+}
+
+// selectPartnerPort resolves the B-End partner port for a cloud VXC of the given connect type.
+func (svc *VXCServiceOp) selectPartnerPort(ctx context.Context, connectType string, selection PartnerPortSelection) (string, error) {
+	if selection.PartnerPortUID != "" {
+		return selection.PartnerPortUID, nil
+	}
+
+	partnerService := svc.Client.PartnerService
+
+	partners, err := partnerService.ListPartnerMegaports(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	partners, err = partnerService.FilterPartnerMegaportByConnectType(ctx, partners, connectType, true)
+	if err != nil {
+		return "", err
+	}
+
+	if selection.ProductName != "" {
+		partners, err = partnerService.FilterPartnerMegaportByProductName(ctx, partners, selection.ProductName, false)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if selection.LocationID != 0 {
+		partners, err = partnerService.FilterPartnerMegaportByLocationId(ctx, partners, selection.LocationID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if selection.DiversityZone != "" {
+		partners, err = partnerService.FilterPartnerMegaportByDiversityZone(ctx, partners, selection.DiversityZone, true)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var permitted []*types.PartnerMegaport
+	for _, partner := range partners {
+		if partner.VXCPermitted {
+			permitted = append(permitted, partner)
+		}
+	}
+	if len(permitted) == 0 {
+		return "", newClientError(ErrNotFound, mega_err.ERR_NO_AVAILABLE_VXC_PORTS)
+	}
+
+	sort.SliceStable(permitted, func(i, j int) bool {
+		return permitted[i].Rank < permitted[j].Rank
+	})
+
+	return permitted[0].ProductUID, nil
+}
+
+// executeVXCOrder submits a VXC order of any shape and returns the confirmation for the first VXC in it. A dry run
+// validates the order and returns its quote instead.
+func (svc *VXCServiceOp) executeVXCOrder(ctx context.Context, buyOrder interface{}, dryRun bool) (*types.VXCOrderConfirmation, error) {
+	if dryRun {
+		quote, err := svc.Client.ProductService.ValidateOrder(ctx, buyOrder)
+		if err != nil {
+			return nil, err
+		}
+		return &types.VXCOrderConfirmation{Quote: quote}, nil
+	}
+
+	responseBody, responseError := svc.Client.ProductService.ExecuteOrder(ctx, buyOrder)
+	if responseError != nil {
+		return nil, responseError
+	}
+
+	orderInfo := types.VXCOrderResponse{}
+	unmarshalErr := json.Unmarshal(*responseBody, &orderInfo)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if len(orderInfo.Data) == 0 {
+		return nil, newClientError(nil, mega_err.ERR_ORDER_NOT_CONFIRMED)
+	}
+
+	return &types.VXCOrderConfirmation{
+		TechnicalServiceUID: orderInfo.Data[0].TechnicalServiceUID,
+	}, nil
+}
+
+type LookupPartnerPortsRequest struct {
+	// Partner is the connect type of the key, e.g. types.CONNECT_TYPE_AZURE.
+	Partner string
+	// Key is the partner-issued key, i.e. the Azure ExpressRoute service key or the Google Cloud pairing key.
+	Key string
+}
+
+// LookupPartnerPorts resolves a partner-issued key to the bandwidths, VLAN and Megaport ports available to it.
+func (svc *VXCServiceOp) LookupPartnerPorts(ctx context.Context, req *LookupPartnerPortsRequest) (*types.PartnerLookup, error) {
+	var path string
+	switch req.Partner {
+	case types.CONNECT_TYPE_AZURE:
+		path = "/v2/secure/azure/" + req.Key
+	case types.CONNECT_TYPE_GOOGLE:
+		path = "/v2/secure/google/" + req.Key
+	default:
+		return nil, newClientError(ErrValidation, mega_err.ERR_INVALID_PARTNER)
+	}
+
+	url := svc.Client.BaseURL.JoinPath(path).String()
+	clientReq, err := svc.Client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := svc.Client.Do(ctx, clientReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	isError, parsedError := svc.Client.IsErrorResponse(response, &err, 200)
+	if isError {
+		return nil, parsedError
+	}
+
+	body, fileErr := io.ReadAll(response.Body)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	lookupResponse := types.PartnerLookupResponse{}
+	unmarshalErr := json.Unmarshal(body, &lookupResponse)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	return &lookupResponse.Data, nil
+}
+
+type BuyAzureVXCRequest struct {
+	PortUID   string
+	VXCName   string
+	RateLimit int
+	AEndVLAN  int
+
+	ServiceKey string
+	// Secondary connects the VXC to the secondary ExpressRoute port instead of the primary.
+	Secondary bool
+	Peers     []types.PartnerOrderAzurePeeringConfig
+
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+// BuyAzureVXC looks up an ExpressRoute service key, picks the matching primary or secondary Megaport port and orders
+// a VXC to it with the requested private and Microsoft peerings.
+func (svc *VXCServiceOp) BuyAzureVXC(ctx context.Context, req *BuyAzureVXCRequest) (*types.VXCOrderConfirmation, error) {
+	if req.ServiceKey == "" {
+		return nil, NewArgError("serviceKey", "it must not be empty")
+	}
+	for i, peer := range req.Peers {
+		if err := validateAzurePeeringConfig(peer); err != nil {
+			return nil, NewArgError(fmt.Sprintf("peers[%d]", i), err.Error())
+		}
+	}
+
+	lookup, err := svc.LookupPartnerPorts(ctx, &LookupPartnerPortsRequest{
+		Partner: types.CONNECT_TYPE_AZURE,
+		Key:     req.ServiceKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if lookup.Bandwidth > 0 && req.RateLimit > lookup.Bandwidth {
+		return nil, NewArgError("rateLimit", fmt.Sprintf("it exceeds the ExpressRoute circuit bandwidth of %d Mbps", lookup.Bandwidth))
+	}
+
+	portType := types.PARTNER_PORT_PRIMARY
+	if req.Secondary {
+		portType = types.PARTNER_PORT_SECONDARY
+	}
+
+	var partnerPortUID string
+	for _, megaport := range lookup.Megaports {
+		if megaport.Type == portType && megaport.VXC == 0 {
+			partnerPortUID = megaport.ProductUID
+			break
+		}
+	}
+	if partnerPortUID == "" {
+		return nil, newClientError(ErrNotFound, mega_err.ERR_NO_AVAILABLE_VXC_PORTS)
+	}
+
+	buyOrder := []types.PartnerOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedVXCs: []types.PartnerOrderContents{
+				{
+					Name:      req.VXCName,
+					RateLimit: req.RateLimit,
+					AEnd: types.VXCOrderAEndConfiguration{
+						VLAN: req.AEndVLAN,
+					},
+					BEnd: types.PartnerOrderBEndConfiguration{
+						PartnerPortID: partnerPortUID,
+						PartnerConfig: types.PartnerOrderAzurePartnerConfig{
+							ConnectType: types.CONNECT_TYPE_AZURE,
+							ServiceKey:  req.ServiceKey,
+							Peers:       req.Peers,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return svc.executeVXCOrder(ctx, buyOrder, req.DryRun)
+}
+
+// validateAzurePeeringConfig checks a single ExpressRoute peering. Both subnets must be distinct /30 IPv4 networks,
+// and Microsoft peerings must advertise public prefixes.
+func validateAzurePeeringConfig(peer types.PartnerOrderAzurePeeringConfig) error {
+	if peer.Type != types.AZURE_PEERING_TYPE_PRIVATE && peer.Type != types.AZURE_PEERING_TYPE_MICROSOFT {
+		return fmt.Errorf("peering type must be %q or %q", types.AZURE_PEERING_TYPE_PRIVATE, types.AZURE_PEERING_TYPE_MICROSOFT)
+	}
+
+	if asn, err := strconv.Atoi(peer.PeerASN); err != nil || asn <= 0 {
+		return fmt.Errorf("peer ASN %q is not a valid ASN", peer.PeerASN)
+	}
+
+	primary, err := parseAzurePeeringSubnet(peer.PrimarySubnet)
+	if err != nil {
+		return fmt.Errorf("primary subnet %s", err)
+	}
+	secondary, err := parseAzurePeeringSubnet(peer.SecondarySubnet)
+	if err != nil {
+		return fmt.Errorf("secondary subnet %s", err)
+	}
+	if primary.Contains(secondary.IP) || secondary.Contains(primary.IP) {
+		return errors.New("primary and secondary subnets must not overlap")
+	}
+
+	if peer.Type == types.AZURE_PEERING_TYPE_MICROSOFT {
+		if primary.IP.IsPrivate() || secondary.IP.IsPrivate() {
+			return errors.New("microsoft peering subnets must be public addresses")
+		}
+		if peer.Prefixes == "" {
+			return errors.New("microsoft peering requires prefixes to advertise")
+		}
+		for _, prefix := range strings.Split(peer.Prefixes, ",") {
+			if _, _, err := net.ParseCIDR(strings.TrimSpace(prefix)); err != nil {
+				return fmt.Errorf("prefix %q is not a valid CIDR", prefix)
+			}
+		}
+	}
+
+	if peer.VLAN < 0 || peer.VLAN > 4094 {
+		return fmt.Errorf("VLAN %d is out of range", peer.VLAN)
+	}
+
+	return nil
+}
+
+func parseAzurePeeringSubnet(subnet string) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(subnet)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("%q is not an IPv4 CIDR", subnet)
+	}
+	if ones, _ := network.Mask.Size(); ones != 30 {
+		return nil, fmt.Errorf("%q must be a /30", subnet)
+	}
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("%q must be a network address, e.g. %s", subnet, network)
+	}
+	return network, nil
+}
+
+type BuyGoogleVXCRequest struct {
+	PortUID   string
+	VXCName   string
+	RateLimit int
+	AEndVLAN  int
+
+	PairingKey string
+	// LocationID optionally picks the Google port in a specific location when the pairing key offers several.
+	LocationID int
+
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+// BuyGoogleVXC looks up a Google Cloud Partner Interconnect pairing key and orders a VXC to an available Megaport
+// port in the pairing key's region.
+func (svc *VXCServiceOp) BuyGoogleVXC(ctx context.Context, req *BuyGoogleVXCRequest) (*types.VXCOrderConfirmation, error) {
+	if req.PairingKey == "" {
+		return nil, NewArgError("pairingKey", "it must not be empty")
+	}
+
+	lookup, err := svc.LookupPartnerPorts(ctx, &LookupPartnerPortsRequest{
+		Partner: types.CONNECT_TYPE_GOOGLE,
+		Key:     req.PairingKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lookup.Bandwidths) > 0 {
+		supported := false
+		for _, bandwidth := range lookup.Bandwidths {
+			if bandwidth == req.RateLimit {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, NewArgError("rateLimit", fmt.Sprintf("%d Mbps is not offered for this pairing key, valid values are %v", req.RateLimit, lookup.Bandwidths))
+		}
+	}
+
+	var partnerPortUID string
+	for _, megaport := range lookup.Megaports {
+		if megaport.VXC != 0 {
+			continue
+		}
+		if req.LocationID != 0 && megaport.LocationID != req.LocationID {
+			continue
+		}
+		partnerPortUID = megaport.ProductUID
+		break
+	}
+	if partnerPortUID == "" {
+		return nil, newClientError(ErrNotFound, mega_err.ERR_NO_AVAILABLE_VXC_PORTS)
+	}
+
+	buyOrder := []types.PartnerOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedVXCs: []types.PartnerOrderContents{
+				{
+					Name:      req.VXCName,
+					RateLimit: req.RateLimit,
+					AEnd: types.VXCOrderAEndConfiguration{
+						VLAN: req.AEndVLAN,
+					},
+					BEnd: types.PartnerOrderBEndConfiguration{
+						PartnerPortID: partnerPortUID,
+						PartnerConfig: types.PartnerOrderGooglePartnerConfig{
+							ConnectType: types.CONNECT_TYPE_GOOGLE,
+							PairingKey:  req.PairingKey,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return svc.executeVXCOrder(ctx, buyOrder, req.DryRun)
+}
+
+type BuyOracleVXCRequest struct {
+	PortUID   string
+	VXCName   string
+	RateLimit int
+	AEndVLAN  int
+
+	PartnerPort PartnerPortSelection
+
+	// VirtualCircuitID is the OCID of the FastConnect virtual circuit, e.g. "ocid1.virtualcircuit.oc1.ap-sydney-1.abc".
+	VirtualCircuitID string
+
+	// DryRun validates the order and quotes its price instead of buying it.
+	DryRun bool
+}
+
+// BuyOracleVXC orders a VXC to an Oracle Cloud FastConnect partner port for an existing virtual circuit.
+func (svc *VXCServiceOp) BuyOracleVXC(ctx context.Context, req *BuyOracleVXCRequest) (*types.VXCOrderConfirmation, error) {
+	if !oracleVirtualCircuitRegex.MatchString(req.VirtualCircuitID) {
+		return nil, NewArgError("virtualCircuitId", fmt.Sprintf("%q is not a FastConnect virtual circuit OCID", req.VirtualCircuitID))
+	}
+
+	partnerPortUID, err := svc.selectPartnerPort(ctx, types.CONNECT_TYPE_ORACLE, req.PartnerPort)
+	if err != nil {
+		return nil, err
+	}
+
+	buyOrder := []types.PartnerOrder{
+		{
+			PortID: req.PortUID,
+			AssociatedVXCs: []types.PartnerOrderContents{
+				{
+					Name:      req.VXCName,
+					RateLimit: req.RateLimit,
+					AEnd: types.VXCOrderAEndConfiguration{
+						VLAN: req.AEndVLAN,
+					},
+					BEnd: types.PartnerOrderBEndConfiguration{
+						PartnerPortID: partnerPortUID,
+						PartnerConfig: types.PartnerOrderOciPartnerConfig{
+							ConnectType:     types.CONNECT_TYPE_ORACLE,
+							VirtualCircutId: req.VirtualCircuitID,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return svc.executeVXCOrder(ctx, buyOrder, req.DryRun)
+}
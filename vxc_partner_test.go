@@ -0,0 +1,336 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuyAWSVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/dropdowns/partner/megaports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testPartnerMegaportsJSON)
+	})
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []types.AWSVXCOrder
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, "a-end-uid", order[0].PortID)
+
+		bEnd := order[0].AssociatedVXCs[0].BEnd
+		assert.Equal(t, "aws-1", bEnd.ProductUID)
+		assert.Equal(t, types.CONNECT_TYPE_AWS_VIF, bEnd.PartnerConfig.ConnectType)
+		assert.Equal(t, types.AWS_VIF_TYPE_PRIVATE, bEnd.PartnerConfig.Type)
+		assert.Equal(t, "123456789012", bEnd.PartnerConfig.OwnerAccount)
+		assert.Equal(t, 65000, bEnd.PartnerConfig.ASN)
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyAWSVXC(ctx, &BuyAWSVXCRequest{
+		PortUID:      "a-end-uid",
+		VXCName:      "Test AWS VIF",
+		RateLimit:    100,
+		AEndVLAN:     200,
+		PartnerPort:  PartnerPortSelection{ProductName: "ap-southeast-2"},
+		ConnectType:  types.CONNECT_TYPE_AWS_VIF,
+		Type:         types.AWS_VIF_TYPE_PRIVATE,
+		OwnerAccount: "123456789012",
+		ASN:          65000,
+		AmazonASN:    64512,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+}
+
+func TestBuyAWSVXC_hostedConnection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		var order []types.AWSVXCOrder
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+
+		partnerConfig := order[0].AssociatedVXCs[0].BEnd.PartnerConfig
+		assert.Equal(t, types.CONNECT_TYPE_AWS_HOSTED_CONNECTION, partnerConfig.ConnectType)
+		assert.Equal(t, "my-hosted-connection", partnerConfig.ConnectionName)
+		assert.Empty(t, partnerConfig.Type)
+		assert.Zero(t, partnerConfig.ASN)
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyAWSVXC(ctx, &BuyAWSVXCRequest{
+		PortUID:        "a-end-uid",
+		VXCName:        "Test AWS Hosted Connection",
+		RateLimit:      100,
+		PartnerPort:    PartnerPortSelection{PartnerPortUID: "aws-2"},
+		ConnectType:    types.CONNECT_TYPE_AWS_HOSTED_CONNECTION,
+		OwnerAccount:   "123456789012",
+		ConnectionName: "my-hosted-connection",
+		ASN:            65000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+}
+
+func TestValidateAWSVXCRequest(t *testing.T) {
+	valid := func() *BuyAWSVXCRequest {
+		return &BuyAWSVXCRequest{
+			ConnectType:       types.CONNECT_TYPE_AWS_VIF,
+			Type:              types.AWS_VIF_TYPE_PUBLIC,
+			OwnerAccount:      "123456789012",
+			ASN:               65000,
+			Prefixes:          "203.0.113.0/24, 198.51.100.0/24",
+			CustomerIPAddress: "203.0.113.1/30",
+			AmazonIPAddress:   "203.0.113.2/30",
+		}
+	}
+	assert.NoError(t, validateAWSVXCRequest(valid()))
+
+	tests := []struct {
+		name   string
+		mutate func(*BuyAWSVXCRequest)
+	}{
+		{"short account", func(r *BuyAWSVXCRequest) { r.OwnerAccount = "12345" }},
+		{"non-numeric account", func(r *BuyAWSVXCRequest) { r.OwnerAccount = "12345678901a" }},
+		{"unknown vif type", func(r *BuyAWSVXCRequest) { r.Type = "transit" }},
+		{"missing asn", func(r *BuyAWSVXCRequest) { r.ASN = 0 }},
+		{"public vif without prefixes", func(r *BuyAWSVXCRequest) { r.Prefixes = "" }},
+		{"public vif with bad prefix", func(r *BuyAWSVXCRequest) { r.Prefixes = "203.0.113.0/24,not-a-prefix" }},
+		{"public vif without addresses", func(r *BuyAWSVXCRequest) { r.CustomerIPAddress, r.AmazonIPAddress = "", "" }},
+		{"public vif with private address", func(r *BuyAWSVXCRequest) { r.CustomerIPAddress = "10.0.0.1/30" }},
+		{"only one address", func(r *BuyAWSVXCRequest) { r.AmazonIPAddress = "" }},
+		{"private vif with prefixes", func(r *BuyAWSVXCRequest) {
+			r.Type = types.AWS_VIF_TYPE_PRIVATE
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			assert.IsType(t, &ArgError{}, validateAWSVXCRequest(req))
+		})
+	}
+
+	invalidPartner := valid()
+	invalidPartner.ConnectType = types.CONNECT_TYPE_AZURE
+	assert.Error(t, validateAWSVXCRequest(invalidPartner))
+}
+
+const testAzureServiceKey = "1b2329a5-56dc-45d0-8a0d-87b706297777"
+
+func TestLookupPartnerPorts_azure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/secure/azure/"+testAzureServiceKey, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, testAzureLookupJSON)
+	})
+
+	lookup, err := client.VXCService.LookupPartnerPorts(ctx, &LookupPartnerPortsRequest{
+		Partner: types.CONNECT_TYPE_AZURE,
+		Key:     testAzureServiceKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, lookup.Bandwidth)
+	assert.Equal(t, 100, lookup.VLAN)
+	assert.Len(t, lookup.Megaports, 2)
+
+	_, err = client.VXCService.LookupPartnerPorts(ctx, &LookupPartnerPortsRequest{Partner: "UNKNOWN", Key: "key"})
+	assert.Error(t, err)
+}
+
+const testAzureLookupJSON = `{"message":"ok","terms":"","data":{
+	"bandwidth":1000,"bandwidths":[50,100,200,500,1000],"vlan":100,"service_key":"` + testAzureServiceKey + `",
+	"megaports":[
+		{"port":1,"type":"primary","productUid":"azure-primary","name":"Azure Primary","locationId":3},
+		{"port":2,"type":"secondary","productUid":"azure-secondary","name":"Azure Secondary","locationId":3}
+	]}}`
+
+func TestBuyAzureVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/secure/azure/"+testAzureServiceKey, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testAzureLookupJSON)
+	})
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		var order []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+
+		bEnd := order[0]["associatedVxcs"].([]interface{})[0].(map[string]interface{})["bEnd"].(map[string]interface{})
+		assert.Equal(t, "azure-secondary", bEnd["productUid"])
+
+		partnerConfig := bEnd["partnerConfig"].(map[string]interface{})
+		assert.Equal(t, types.CONNECT_TYPE_AZURE, partnerConfig["connectType"])
+		assert.Equal(t, testAzureServiceKey, partnerConfig["serviceKey"])
+
+		peer := partnerConfig["peers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, types.AZURE_PEERING_TYPE_PRIVATE, peer["type"])
+		assert.Equal(t, "192.168.100.0/30", peer["primary_subnet"])
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyAzureVXC(ctx, &BuyAzureVXCRequest{
+		PortUID:    "a-end-uid",
+		VXCName:    "Test Azure VXC",
+		RateLimit:  200,
+		ServiceKey: testAzureServiceKey,
+		Secondary:  true,
+		Peers: []types.PartnerOrderAzurePeeringConfig{
+			{
+				Type:            types.AZURE_PEERING_TYPE_PRIVATE,
+				PeerASN:         "64555",
+				PrimarySubnet:   "192.168.100.0/30",
+				SecondarySubnet: "192.168.100.4/30",
+				VLAN:            101,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+
+	_, err = client.VXCService.BuyAzureVXC(ctx, &BuyAzureVXCRequest{
+		PortUID:    "a-end-uid",
+		RateLimit:  10000,
+		ServiceKey: testAzureServiceKey,
+	})
+	assert.IsType(t, &ArgError{}, err)
+}
+
+func TestValidateAzurePeeringConfig(t *testing.T) {
+	valid := func() types.PartnerOrderAzurePeeringConfig {
+		return types.PartnerOrderAzurePeeringConfig{
+			Type:            types.AZURE_PEERING_TYPE_MICROSOFT,
+			PeerASN:         "64555",
+			PrimarySubnet:   "203.0.113.0/30",
+			SecondarySubnet: "203.0.113.4/30",
+			Prefixes:        "203.0.113.128/25",
+			VLAN:            200,
+		}
+	}
+	assert.NoError(t, validateAzurePeeringConfig(valid()))
+
+	tests := []struct {
+		name   string
+		mutate func(*types.PartnerOrderAzurePeeringConfig)
+	}{
+		{"unknown type", func(p *types.PartnerOrderAzurePeeringConfig) { p.Type = "public" }},
+		{"bad asn", func(p *types.PartnerOrderAzurePeeringConfig) { p.PeerASN = "AS64555" }},
+		{"primary not /30", func(p *types.PartnerOrderAzurePeeringConfig) { p.PrimarySubnet = "203.0.113.0/29" }},
+		{"secondary not network address", func(p *types.PartnerOrderAzurePeeringConfig) { p.SecondarySubnet = "203.0.113.5/30" }},
+		{"ipv6 subnet", func(p *types.PartnerOrderAzurePeeringConfig) { p.PrimarySubnet = "2001:db8::/126" }},
+		{"overlapping subnets", func(p *types.PartnerOrderAzurePeeringConfig) { p.SecondarySubnet = p.PrimarySubnet }},
+		{"microsoft private subnet", func(p *types.PartnerOrderAzurePeeringConfig) {
+			p.PrimarySubnet, p.SecondarySubnet = "10.0.0.0/30", "10.0.0.4/30"
+		}},
+		{"microsoft without prefixes", func(p *types.PartnerOrderAzurePeeringConfig) { p.Prefixes = "" }},
+		{"vlan out of range", func(p *types.PartnerOrderAzurePeeringConfig) { p.VLAN = 4095 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peer := valid()
+			tt.mutate(&peer)
+			assert.Error(t, validateAzurePeeringConfig(peer))
+		})
+	}
+}
+
+const testGooglePairingKey = "7e51371e-72a3-40b5-b844-2e3efefaee59/australia-southeast1/2"
+
+func TestBuyGoogleVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/secure/google/"+testGooglePairingKey, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{
+			"bandwidths":[50,100,200,300,400,500,1000],"resource_type":"csp_connection",
+			"megaports":[
+				{"port":1,"productUid":"google-used","locationId":3,"vxc":99},
+				{"port":2,"productUid":"google-sydney","locationId":3},
+				{"port":3,"productUid":"google-melbourne","locationId":5}
+			]}}`)
+	})
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		var order []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+
+		bEnd := order[0]["associatedVxcs"].([]interface{})[0].(map[string]interface{})["bEnd"].(map[string]interface{})
+		assert.Equal(t, "google-sydney", bEnd["productUid"])
+
+		partnerConfig := bEnd["partnerConfig"].(map[string]interface{})
+		assert.Equal(t, types.CONNECT_TYPE_GOOGLE, partnerConfig["connectType"])
+		assert.Equal(t, testGooglePairingKey, partnerConfig["pairingKey"])
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyGoogleVXC(ctx, &BuyGoogleVXCRequest{
+		PortUID:    "a-end-uid",
+		VXCName:    "Test Google VXC",
+		RateLimit:  100,
+		PairingKey: testGooglePairingKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+
+	_, err = client.VXCService.BuyGoogleVXC(ctx, &BuyGoogleVXCRequest{
+		PortUID:    "a-end-uid",
+		RateLimit:  150,
+		PairingKey: testGooglePairingKey,
+	})
+	assert.IsType(t, &ArgError{}, err)
+}
+
+func TestBuyOracleVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	virtualCircuitID := "ocid1.virtualcircuit.oc1.ap-sydney-1.aaaaaaaabbbbbbbbccccccccdddddddd"
+
+	mux.HandleFunc("/v2/dropdowns/partner/megaports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[
+			{"connectType":"ORACLE","productUid":"oracle-2","title":"Oracle Sydney 2","locationId":3,"rank":2,"vxcPermitted":true},
+			{"connectType":"ORACLE","productUid":"oracle-1","title":"Oracle Sydney 1","locationId":3,"rank":1,"vxcPermitted":true}
+		]}`)
+	})
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		var order []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+
+		bEnd := order[0]["associatedVxcs"].([]interface{})[0].(map[string]interface{})["bEnd"].(map[string]interface{})
+		assert.Equal(t, "oracle-1", bEnd["productUid"])
+
+		partnerConfig := bEnd["partnerConfig"].(map[string]interface{})
+		assert.Equal(t, types.CONNECT_TYPE_ORACLE, partnerConfig["connectType"])
+		assert.Equal(t, virtualCircuitID, partnerConfig["virtualCircuitId"])
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyOracleVXC(ctx, &BuyOracleVXCRequest{
+		PortUID:          "a-end-uid",
+		VXCName:          "Test Oracle VXC",
+		RateLimit:        1000,
+		VirtualCircuitID: virtualCircuitID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+
+	_, err = client.VXCService.BuyOracleVXC(ctx, &BuyOracleVXCRequest{PortUID: "a-end-uid", VirtualCircuitID: "not-an-ocid"})
+	assert.IsType(t, &ArgError{}, err)
+}
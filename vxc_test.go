@@ -0,0 +1,149 @@
+package megaport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/megaport/megaportgo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testVXCUID = "3d8a4a3b-1f5c-4e4a-9d6c-1b2a3c4d5e6f"
+
+func TestBuyVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var order []types.VXCOrder
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		assert.Len(t, order, 1)
+		assert.Equal(t, "a-end-uid", order[0].PortID)
+		assert.Equal(t, "Test VXC", order[0].AssociatedVXCs[0].Name)
+		assert.Equal(t, 500, order[0].AssociatedVXCs[0].RateLimit)
+		assert.Equal(t, 100, order[0].AssociatedVXCs[0].AEnd.VLAN)
+		assert.Equal(t, "b-end-uid", order[0].AssociatedVXCs[0].BEnd.ProductUID)
+
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":[{"vxcJTechnicalServiceUid":%q}]}`, testVXCUID)
+	})
+
+	confirmation, err := client.VXCService.BuyVXC(ctx, &BuyVXCRequest{
+		PortUID:           "a-end-uid",
+		VXCName:           "Test VXC",
+		RateLimit:         500,
+		AEndConfiguration: types.VXCOrderAEndConfiguration{VLAN: 100},
+		BEndConfiguration: types.VXCOrderBEndConfiguration{ProductUID: "b-end-uid", VLAN: 200},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, confirmation.TechnicalServiceUID)
+}
+
+func TestGetVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"productName":"Test VXC","rateLimit":500,"provisioningStatus":"LIVE","aEnd":{"productUid":"a-end-uid","vlan":100},"bEnd":{"productUid":"b-end-uid","vlan":200}}}`, testVXCUID)
+	})
+
+	vxc, err := client.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+	assert.NoError(t, err)
+	assert.Equal(t, testVXCUID, vxc.UID)
+	assert.Equal(t, "Test VXC", vxc.Name)
+	assert.Equal(t, 500, vxc.RateLimit)
+	assert.Equal(t, "a-end-uid", vxc.AEndConfiguration.UID)
+	assert.Equal(t, 200, vxc.BEndConfiguration.VLAN)
+}
+
+func TestGetVXC_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Could not find a service with UID","terms":"","data":"not found"}`)
+	})
+
+	vxc, err := client.VXCService.GetVXC(ctx, &GetVXCRequest{VXCID: testVXCUID})
+	assert.Nil(t, vxc)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestUpdateVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/vxc/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		update := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		assert.Equal(t, "Renamed VXC", update["name"])
+		assert.EqualValues(t, 1000, update["rateLimit"])
+		assert.EqualValues(t, 101, update["aEndVlan"])
+		assert.NotContains(t, update, "bEndVlan")
+
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":{}}`)
+	})
+
+	resp, err := client.VXCService.UpdateVXC(ctx, &UpdateVXCRequest{
+		VXCID:     testVXCUID,
+		Name:      "Renamed VXC",
+		RateLimit: 1000,
+		AEndVLAN:  101,
+	})
+	assert.NoError(t, err)
+	assert.True(t, resp.IsUpdated)
+}
+
+func TestDeleteVXC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/product/"+testVXCUID+"/action/CANCEL_NOW", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"message":"ok","terms":""}`)
+	})
+
+	resp, err := client.VXCService.DeleteVXC(ctx, &DeleteVXCRequest{VXCID: testVXCUID, DeleteNow: true})
+	assert.NoError(t, err)
+	assert.True(t, resp.IsDeleting)
+}
+
+func TestWaitForVXCProvisioning(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"provisioningStatus":%q}}`, testVXCUID, shared.SERVICE_LIVE)
+	})
+
+	provisioned, err := client.VXCService.WaitForVXCProvisioning(ctx, testVXCUID)
+	assert.NoError(t, err)
+	assert.True(t, provisioned)
+}
+
+func TestBuyVXC_unconfirmed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v3/networkdesign/buy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message":"ok","terms":"","data":[]}`)
+	})
+
+	_, err := client.VXCService.BuyVXC(ctx, &BuyVXCRequest{
+		PortUID:           "a-end-uid",
+		VXCName:           "Test VXC",
+		RateLimit:         500,
+		AEndConfiguration: types.VXCOrderAEndConfiguration{VLAN: 100},
+		BEndConfiguration: types.VXCOrderBEndConfiguration{ProductUID: "b-end-uid", VLAN: 200},
+	})
+	assert.EqualError(t, err, mega_err.ERR_ORDER_NOT_CONFIRMED)
+}
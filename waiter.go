@@ -0,0 +1,152 @@
+package megaport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+)
+
+const (
+	defaultWaitPollInterval = 10 * time.Second
+	defaultWaitTimeout      = 5 * time.Minute
+)
+
+// WaitOptions configures how WaitForProvisioning and the WaitFor*Provisioning methods poll a product until it reaches a target state.
+// Zero values fall back to polling every 10 seconds for up to 5 minutes until the product is CONFIGURED or LIVE.
+type WaitOptions struct {
+	// PollInterval is the delay before the first re-poll.
+	PollInterval time.Duration
+	// MaxPollInterval caps the poll interval when Backoff is used. Zero means no cap.
+	MaxPollInterval time.Duration
+	// Backoff multiplies the poll interval after every poll. Values <= 1 keep the interval constant.
+	Backoff float64
+	// Timeout is the total time to wait before giving up with a *ProvisionTimeoutError.
+	Timeout time.Duration
+	// TargetStates are the provisioning states that end the wait successfully.
+	TargetStates []string
+	// OnProgress, if set, is called after every poll that has not yet reached a target state.
+	OnProgress func(status string, elapsed time.Duration)
+}
+
+// ProvisionTimeoutError is returned when a product does not reach a target state within WaitOptions.Timeout.
+type ProvisionTimeoutError struct {
+	// Message is the product-specific timeout message from mega_err.
+	Message     string
+	ProductType string
+	ProductUID  string
+	// LastStatus is the provisioning status seen on the final poll.
+	LastStatus string
+	Elapsed    time.Duration
+}
+
+var _ error = &ProvisionTimeoutError{}
+
+func (e *ProvisionTimeoutError) Error() string {
+	product := strings.TrimSpace(e.ProductType + " " + e.ProductUID)
+	return fmt.Sprintf("%s: %s was still %q after %s", e.Message, product, e.LastStatus, e.Elapsed.Round(time.Second))
+}
+
+// Is reports whether target is ErrProvisionTimeout.
+func (e *ProvisionTimeoutError) Is(target error) bool {
+	return target == ErrProvisionTimeout
+}
+
+// SetWaitOptions is a client option for setting the options used by the WaitFor*Provisioning methods.
+func SetWaitOptions(opts WaitOptions) ClientOpt {
+	return func(c *Client) error {
+		c.WaitOptions = opts
+		return nil
+	}
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultWaitPollInterval
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultWaitTimeout
+	}
+	if len(o.TargetStates) == 0 {
+		o.TargetStates = shared.SERVICE_STATE_READY
+	}
+	return o
+}
+
+// WaitForProvisioning polls getStatus until it returns one of the target states in opts, the timeout elapses, or ctx is
+// done. A nil opts uses the defaults described on WaitOptions. Unlike the WaitFor*Provisioning methods, which use the
+// client's WaitOptions, it lets a single call wait with its own options or on a status the SDK does not poll itself.
+func WaitForProvisioning(ctx context.Context, opts *WaitOptions, getStatus func(ctx context.Context) (string, error)) error {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	return pollProvisioning(ctx, nil, *opts, "product", "", mega_err.ERR_PRODUCT_PROVISION_TIMEOUT_EXCEED, getStatus)
+}
+
+// waitForProvisioning polls getStatus with the client's WaitOptions. timeoutMessage is the mega_err message carried
+// by the *ProvisionTimeoutError.
+func (c *Client) waitForProvisioning(ctx context.Context, productType, productUID, timeoutMessage string, getStatus func(ctx context.Context) (string, error)) error {
+	return pollProvisioning(ctx, c.Logger, c.WaitOptions, productType, productUID, timeoutMessage, getStatus)
+}
+
+// pollProvisioning implements WaitForProvisioning. logger may be nil.
+func pollProvisioning(ctx context.Context, logger *slog.Logger, opts WaitOptions, productType, productUID, timeoutMessage string, getStatus func(ctx context.Context) (string, error)) error {
+	opts = opts.withDefaults()
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+	interval := opts.PollInterval
+
+	for {
+		status, err := getStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, target := range opts.TargetStates {
+			if status == target {
+				return nil
+			}
+		}
+
+		elapsed := time.Since(start)
+		if opts.OnProgress != nil {
+			opts.OnProgress(status, elapsed)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return &ProvisionTimeoutError{
+				Message:     timeoutMessage,
+				ProductType: productType,
+				ProductUID:  productUID,
+				LastStatus:  status,
+				Elapsed:     elapsed,
+			}
+		}
+
+		// Wrong status, wait a bit and try again.
+		if logger != nil {
+			logger.Debug(fmt.Sprintf("%s status is currently %q - waiting", productType, status), "status", status, "product_uid", productUID)
+		}
+
+		timer := time.NewTimer(min(interval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if opts.Backoff > 1 {
+			interval = time.Duration(float64(interval) * opts.Backoff)
+			if opts.MaxPollInterval > 0 && interval > opts.MaxPollInterval {
+				interval = opts.MaxPollInterval
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package megaport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/megaport/megaportgo/mega_err"
+	"github.com/megaport/megaportgo/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+// handleProvisioningStatuses serves the given statuses in order for the VXC product, repeating the last one.
+func handleProvisioningStatuses(statuses ...string) *int {
+	polls := 0
+	mux.HandleFunc("/v2/product/"+testVXCUID, func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		fmt.Fprintf(w, `{"message":"ok","terms":"","data":{"productUid":%q,"provisioningStatus":%q}}`, testVXCUID, status)
+	})
+	return &polls
+}
+
+func TestWaitForProvisioning_configured(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := handleProvisioningStatuses("DEPLOYABLE", "DEPLOYABLE", shared.SERVICE_CONFIGURED)
+
+	var seen []string
+	client.WaitOptions = WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+		OnProgress: func(status string, elapsed time.Duration) {
+			seen = append(seen, status)
+		},
+	}
+
+	ready, err := client.VXCService.WaitForVXCProvisioning(ctx, testVXCUID)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, 3, *polls)
+	assert.Equal(t, []string{"DEPLOYABLE", "DEPLOYABLE"}, seen)
+}
+
+func TestWaitForProvisioning_targetStates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := handleProvisioningStatuses(shared.SERVICE_CONFIGURED, shared.SERVICE_LIVE)
+
+	client.WaitOptions = WaitOptions{
+		PollInterval: time.Millisecond,
+		Backoff:      2,
+		Timeout:      time.Second,
+		TargetStates: []string{shared.SERVICE_LIVE},
+	}
+
+	ready, err := client.VXCService.WaitForVXCProvisioning(ctx, testVXCUID)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, 2, *polls)
+}
+
+func TestWaitForProvisioning_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleProvisioningStatuses("DEPLOYABLE")
+
+	client.WaitOptions = WaitOptions{
+		PollInterval:    time.Millisecond,
+		Backoff:         1.5,
+		MaxPollInterval: 5 * time.Millisecond,
+		Timeout:         20 * time.Millisecond,
+	}
+
+	ready, err := client.VXCService.WaitForVXCProvisioning(ctx, testVXCUID)
+	assert.False(t, ready)
+
+	assert.ErrorIs(t, err, ErrProvisionTimeout)
+
+	var timeoutErr *ProvisionTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, mega_err.ERR_VXC_PROVISION_TIMEOUT_EXCEED, timeoutErr.Message)
+	assert.Equal(t, "VXC", timeoutErr.ProductType)
+	assert.Equal(t, testVXCUID, timeoutErr.ProductUID)
+	assert.Equal(t, "DEPLOYABLE", timeoutErr.LastStatus)
+	assert.GreaterOrEqual(t, timeoutErr.Elapsed, 20*time.Millisecond)
+}
+
+func TestWaitForProvisioning_contextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleProvisioningStatuses("DEPLOYABLE")
+
+	client.WaitOptions = WaitOptions{
+		PollInterval: time.Hour,
+	}
+
+	cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	ready, err := client.VXCService.WaitForVXCProvisioning(cancelCtx, testVXCUID)
+	assert.False(t, ready)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForProvisioning_perCallOptions(t *testing.T) {
+	statuses := []string{"DEPLOYABLE", shared.SERVICE_CONFIGURED, shared.SERVICE_LIVE}
+	polls := 0
+	getStatus := func(ctx context.Context) (string, error) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		return status, nil
+	}
+
+	var seen []string
+	err := WaitForProvisioning(ctx, &WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+		TargetStates: []string{shared.SERVICE_LIVE},
+		OnProgress: func(status string, elapsed time.Duration) {
+			seen = append(seen, status)
+		},
+	}, getStatus)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, []string{"DEPLOYABLE", shared.SERVICE_CONFIGURED}, seen)
+}
+
+func TestWaitForProvisioning_perCallTimeout(t *testing.T) {
+	err := WaitForProvisioning(ctx, &WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      10 * time.Millisecond,
+	}, func(ctx context.Context) (string, error) {
+		return "DEPLOYABLE", nil
+	})
+	assert.ErrorIs(t, err, ErrProvisionTimeout)
+
+	var timeoutErr *ProvisionTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, mega_err.ERR_PRODUCT_PROVISION_TIMEOUT_EXCEED, timeoutErr.Message)
+	assert.Equal(t, "DEPLOYABLE", timeoutErr.LastStatus)
+}
+
+func TestWaitForProvisioning_nilOptions(t *testing.T) {
+	err := WaitForProvisioning(ctx, nil, func(ctx context.Context) (string, error) {
+		return shared.SERVICE_LIVE, nil
+	})
+	assert.NoError(t, err)
+}